@@ -0,0 +1,274 @@
+package tic80
+
+import "errors"
+
+// MouseButton is an enumeration of the mouse buttons, for binding to an action.
+type MouseButton int
+
+// Mouse Buttons
+const (
+	MOUSE_LEFT MouseButton = iota
+	MOUSE_MIDDLE
+	MOUSE_RIGHT
+)
+
+// Binding is an input that an action is bound to, which is either a gamepad button, a key or a mouse button.
+// It is stored in one byte, so that the bindings of an action can be persisted with [tic80.Pmem].
+type Binding byte
+
+// Raw ranges of a [tic80.Binding].
+const (
+	bindingButtonFirst = 0x01
+	bindingMouseFirst  = 0x21
+	bindingKeyFirst    = 0x80
+	bindingsPerValue   = 4
+	bindingsNone       = 0xFFFFFFFF
+)
+
+// MAXIMUM_BINDINGS is the most bindings an action can have, so that they can be persisted in one value of [tic80.Pmem].
+const MAXIMUM_BINDINGS = bindingsPerValue
+
+// BindButton returns the binding of a gamepad button.
+func BindButton(id ButtonCode) Binding {
+	return Binding(bindingButtonFirst + int(id)%32)
+}
+
+// BindKey returns the binding of a key.
+func BindKey(id KeyCode) Binding {
+	return Binding(bindingKeyFirst + int(id%(KEY_ALT+1)))
+}
+
+// BindMouse returns the binding of a mouse button.
+func BindMouse(button MouseButton) Binding {
+	return Binding(bindingMouseFirst + int(button)%3)
+}
+
+// Button returns the gamepad button of the binding, and true if it is one.
+func (binding Binding) Button() (ButtonCode, bool) {
+	if binding >= bindingButtonFirst && binding < bindingButtonFirst+32 {
+		return ButtonCode(binding - bindingButtonFirst), true
+	}
+	return 0, false
+}
+
+// Key returns the key of the binding, and true if it is one.
+func (binding Binding) Key() (KeyCode, bool) {
+	if binding > bindingKeyFirst && binding <= bindingKeyFirst+Binding(KEY_ALT) {
+		return KeyCode(binding - bindingKeyFirst), true
+	}
+	return 0, false
+}
+
+// Mouse returns the mouse button of the binding, and true if it is one.
+func (binding Binding) Mouse() (MouseButton, bool) {
+	if binding >= bindingMouseFirst && binding < bindingMouseFirst+3 {
+		return MouseButton(binding - bindingMouseFirst), true
+	}
+	return 0, false
+}
+
+// Pressed returns true if the input of the binding is pressed; false otherwise.
+func (binding Binding) Pressed() bool {
+	if id, ok := binding.Button(); ok {
+		return GAMEPADS.Pressed(id)
+	}
+	if id, ok := binding.Key(); ok {
+		return KEYBOARD.Pressed(id)
+	}
+	if button, ok := binding.Mouse(); ok {
+		left, middle, right := MOUSE.Buttons()
+		return [3]bool{left, middle, right}[button]
+	}
+	return false
+}
+
+// action is the state of one action of [tic80.Actions].
+type action struct {
+	defaults []Binding
+	bindings []Binding
+	held     int
+	released bool
+}
+
+// Actions maps named actions to any mix of gamepad buttons, keys and mouse buttons, which players can rebind.
+// Game logic then asks about actions, such as "jump", rather than about the inputs bound to them.
+//
+// [tic80.Actions.Update] must be called once every frame, at the start of [tic80.Game.Tic].
+type Actions struct {
+	actions []action
+	indices map[string]int
+}
+
+// NewActions constructs a [tic80.Actions] object with no actions.
+func NewActions() *Actions {
+	return &Actions{indices: make(map[string]int)}
+}
+
+// find returns the action with the specified name, or nil if there is none.
+func (actions *Actions) find(name string) *action {
+	if index, found := actions.indices[name]; found {
+		return &actions.actions[index]
+	}
+	return nil
+}
+
+// limitBindings returns a copy of the bindings, without those past [tic80.MAXIMUM_BINDINGS].
+func limitBindings(bindings []Binding) []Binding {
+	if len(bindings) > MAXIMUM_BINDINGS {
+		bindings = bindings[:MAXIMUM_BINDINGS]
+	}
+	return append([]Binding(nil), bindings...)
+}
+
+// Bind adds an action with its default bindings, or adds the bindings to the action if it already exists.
+// An action has at most [tic80.MAXIMUM_BINDINGS] bindings, and the bindings past them are ignored.
+// Actions are persisted in the order they are first bound, so new actions should be bound after the existing ones.
+func (actions *Actions) Bind(name string, bindings ...Binding) *Actions {
+	if bound := actions.find(name); bound != nil {
+		bound.defaults = limitBindings(append(bound.defaults, bindings...))
+		bound.bindings = limitBindings(append(bound.bindings, bindings...))
+		return actions
+	}
+	actions.indices[name] = len(actions.actions)
+	actions.actions = append(actions.actions, action{
+		defaults: limitBindings(bindings),
+		bindings: limitBindings(bindings),
+	})
+	return actions
+}
+
+// Rebind replaces the bindings of an action, which may be none to unbind it.
+// It returns an error, and leaves the bindings unchanged, if there are more than [tic80.MAXIMUM_BINDINGS].
+func (actions *Actions) Rebind(name string, bindings ...Binding) error {
+	if len(bindings) > MAXIMUM_BINDINGS {
+		return errors.New("tic80: an action has at most four bindings")
+	}
+	if bound := actions.find(name); bound != nil {
+		bound.bindings = append([]Binding(nil), bindings...)
+	}
+	return nil
+}
+
+// Reset restores the default bindings of every action.
+func (actions *Actions) Reset() {
+	for index := range actions.actions {
+		bound := &actions.actions[index]
+		bound.bindings = append([]Binding(nil), bound.defaults...)
+	}
+}
+
+// Bindings returns the bindings of an action.
+func (actions *Actions) Bindings(name string) []Binding {
+	if bound := actions.find(name); bound != nil {
+		return append([]Binding(nil), bound.bindings...)
+	}
+	return nil
+}
+
+// Update reads the input of every action for this frame.
+func (actions *Actions) Update() {
+	for index := range actions.actions {
+		bound := &actions.actions[index]
+		pressed := false
+		for _, binding := range bound.bindings {
+			if binding.Pressed() {
+				pressed = true
+				break
+			}
+		}
+		bound.released = !pressed && bound.held > 0
+		if pressed {
+			bound.held++
+		} else {
+			bound.held = 0
+		}
+	}
+}
+
+// Pressed returns true if any input bound to an action is pressed; false otherwise.
+func (actions *Actions) Pressed(name string) bool {
+	return actions.HeldFrames(name) > 0
+}
+
+// JustPressed returns true if an action was pressed this frame, after not being pressed the frame before; false otherwise.
+func (actions *Actions) JustPressed(name string) bool {
+	return actions.HeldFrames(name) == 1
+}
+
+// Released returns true if an action stopped being pressed this frame; false otherwise.
+func (actions *Actions) Released(name string) bool {
+	if bound := actions.find(name); bound != nil {
+		return bound.released
+	}
+	return false
+}
+
+// HeldFrames returns the number of frames an action has been pressed for, including this one, or 0 if it is not pressed.
+func (actions *Actions) HeldFrames(name string) int {
+	if bound := actions.find(name); bound != nil {
+		return bound.held
+	}
+	return 0
+}
+
+// Listen returns the first gamepad button, key or mouse button that is pressed, for choosing a new binding,
+// and true if any is pressed.
+func Listen() (Binding, bool) {
+	for id := ButtonCode(0); id < 32; id++ {
+		if GAMEPADS.Pressed(id) {
+			return BindButton(id), true
+		}
+	}
+	if keys := KEYBOARD.Keys(); len(keys) > 0 {
+		return BindKey(keys[0]), true
+	}
+	for button := MOUSE_LEFT; button <= MOUSE_RIGHT; button++ {
+		if BindMouse(button).Pressed() {
+			return BindMouse(button), true
+		}
+	}
+	return 0, false
+}
+
+// Save persists the bindings of every action with [tic80.Pmem], starting at the specified address,
+// in one value per action, so that actions that were unbound stay unbound.
+// It returns the address after the last value written.
+func (actions *Actions) Save(address int) int {
+	for _, bound := range actions.actions {
+		value := uint32(bindingsNone)
+		if len(bound.bindings) > 0 {
+			value = 0
+			for index, binding := range bound.bindings {
+				value |= uint32(binding) << (index * 8)
+			}
+		}
+		Pmem(address, int64(value))
+		address++
+	}
+	return address
+}
+
+// Load restores the bindings of every action persisted with [tic80.Actions.Save] at the specified address.
+// Actions that have no bindings persisted keep their current bindings.
+// It returns the address after the last value read.
+func (actions *Actions) Load(address int) int {
+	for index := range actions.actions {
+		value := Pmem(address, -1)
+		address++
+		if value == 0 {
+			continue
+		}
+		if value == bindingsNone {
+			actions.actions[index].bindings = nil
+			continue
+		}
+		var bindings []Binding
+		for shift := 0; shift < bindingsPerValue*8; shift += 8 {
+			if binding := Binding(value >> shift); binding != 0 {
+				bindings = append(bindings, binding)
+			}
+		}
+		actions.actions[index].bindings = bindings
+	}
+	return address
+}
@@ -0,0 +1,113 @@
+// Package audio renders the sound effects and music of a cartridge offline, with the synthesizer of the host.
+//
+// Rendering resets the host with [tic80.HostReset], so it should not be mixed with a game running on the host.
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sorucoder/tic80"
+	"github.com/sorucoder/tic80/cart"
+)
+
+// Audio is rendered sound, as interleaved 16-bit stereo samples.
+type Audio struct {
+	SampleRate int
+	Samples    []int16
+}
+
+// render resets the host, loads a bank of the cartridge and starts playing,
+// then renders frames until the sound ends or the maximum number of frames is reached.
+func render(cartridge *cart.Cart, bank int, play func(), maximumFrames int) *Audio {
+	tic80.HostReset()
+	cartridge.Load(bank)
+	play()
+
+	// The first frame synthesizes the registers from before the sound started, so it is silent.
+	tic80.HostAdvance()
+	tic80.HostAudio()
+
+	rendered := &Audio{SampleRate: tic80.HOST_SAMPLE_RATE}
+	for frame := 0; frame < maximumFrames && tic80.HostSoundPlaying(); frame++ {
+		tic80.HostAdvance()
+		rendered.Samples = append(rendered.Samples, tic80.HostAudio()...)
+	}
+	return rendered
+}
+
+// RenderSoundEffect renders a sound effect of a bank of the cartridge, played with the options as [tic80.Sfx] does.
+// It renders until the sound effect ends, or for the maximum number of frames,
+// since a sound effect without a duration that loops or sustains its last tick never ends.
+func RenderSoundEffect(cartridge *cart.Cart, bank int, options *tic80.SoundEffectOptions, maximumFrames int) *Audio {
+	return render(cartridge, bank, func() { tic80.Sfx(options) }, maximumFrames)
+}
+
+// RenderMusic renders a music track of a bank of the cartridge, played with the options as [tic80.Music] does.
+// It renders until the track ends, or for the maximum number of frames, since a looping track never ends.
+func RenderMusic(cartridge *cart.Cart, bank int, options *tic80.MusicOptions, maximumFrames int) *Audio {
+	return render(cartridge, bank, func() { tic80.Music(options) }, maximumFrames)
+}
+
+// Duration returns the length of the audio.
+func (rendered *Audio) Duration() time.Duration {
+	if rendered.SampleRate == 0 {
+		return 0
+	}
+	return time.Duration(len(rendered.Samples)/2) * time.Second / time.Duration(rendered.SampleRate)
+}
+
+// Silent returns true if every sample of the audio is silent; false otherwise.
+func (rendered *Audio) Silent() bool {
+	for _, sample := range rendered.Samples {
+		if sample != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteWAV writes the audio as a 16-bit stereo PCM WAV file.
+func (rendered *Audio) WriteWAV(writer io.Writer) error {
+	const channels, bytesPerSample = 2, 2
+	size := len(rendered.Samples) * bytesPerSample
+
+	header := make([]byte, 0, 44)
+	header = append(header, "RIFF"...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(36+size))
+	header = append(header, "WAVEfmt "...)
+	header = binary.LittleEndian.AppendUint32(header, 16)
+	header = binary.LittleEndian.AppendUint16(header, 1)
+	header = binary.LittleEndian.AppendUint16(header, channels)
+	header = binary.LittleEndian.AppendUint32(header, uint32(rendered.SampleRate))
+	header = binary.LittleEndian.AppendUint32(header, uint32(rendered.SampleRate*channels*bytesPerSample))
+	header = binary.LittleEndian.AppendUint16(header, channels*bytesPerSample)
+	header = binary.LittleEndian.AppendUint16(header, bytesPerSample*8)
+	header = append(header, "data"...)
+	header = binary.LittleEndian.AppendUint32(header, uint32(size))
+	if _, err := writer.Write(header); err != nil {
+		return err
+	}
+
+	data := make([]byte, 0, size)
+	for _, sample := range rendered.Samples {
+		data = binary.LittleEndian.AppendUint16(data, uint16(sample))
+	}
+	_, err := writer.Write(data)
+	return err
+}
+
+// WriteWAVFile writes the audio to a 16-bit stereo PCM WAV file.
+func (rendered *Audio) WriteWAVFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := rendered.WriteWAV(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
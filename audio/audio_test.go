@@ -0,0 +1,107 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sorucoder/tic80"
+	"github.com/sorucoder/tic80/cart"
+)
+
+// transitions returns the number of times the left channel of the audio changes value.
+func transitions(rendered *Audio) int {
+	count := 0
+	for index := 2; index < len(rendered.Samples); index += 2 {
+		if rendered.Samples[index] != rendered.Samples[index-2] {
+			count++
+		}
+	}
+	return count
+}
+
+func TestRenderSoundEffect(t *testing.T) {
+	muted := cart.New()
+	for tick := 0; tick < tic80.SOUND_EFFECT_TICKS; tick++ {
+		muted.Banks[0].SoundEffects.SoundEffect(0).SetVolume(tick, 15)
+	}
+
+	tests := []struct {
+		name        string
+		cart        *cart.Cart
+		silent      bool
+		transitions int
+	}{
+		// The default sound effect plays E3 at about 164.81Hz with the default square wave,
+		// which has two periods per waveform, so the left channel changes value four times per period.
+		{"default", cart.New(), false, int(math.Round(4 * 164.81 * 29 / tic80.HOST_FRAME_RATE))},
+		{"muted", muted, true, 0},
+	}
+	for _, test := range tests {
+		rendered := RenderSoundEffect(test.cart, 0, tic80.NewSoundEffectOptions().SetId(0).SetDuration(30), 120)
+		if rendered.SampleRate != tic80.HOST_SAMPLE_RATE {
+			t.Errorf("%s: sample rate is %d, expected %d", test.name, rendered.SampleRate, tic80.HOST_SAMPLE_RATE)
+		}
+		// As in TIC-80, the duration is counted down on the frame the sound effect starts, before it is heard.
+		if expected := 29 * tic80.HOST_SAMPLE_RATE / tic80.HOST_FRAME_RATE * 2; len(rendered.Samples) != expected {
+			t.Errorf("%s: rendered %d samples, expected %d", test.name, len(rendered.Samples), expected)
+		}
+		if rendered.Silent() != test.silent {
+			t.Errorf("%s: silent is %v, expected %v", test.name, rendered.Silent(), test.silent)
+		}
+		if count := transitions(rendered); count < test.transitions-2 || count > test.transitions+2 {
+			t.Errorf("%s: left channel changes %d times, expected about %d", test.name, count, test.transitions)
+		}
+	}
+}
+
+func TestWriteWAV(t *testing.T) {
+	rendered := &Audio{SampleRate: 22050, Samples: []int16{1, -1, 0x1234, -0x1234}}
+	var buffer bytes.Buffer
+	if err := rendered.WriteWAV(&buffer); err != nil {
+		t.Fatal(err)
+	}
+	data := buffer.Bytes()
+	if len(data) != 44+8 {
+		t.Fatalf("wrote %d bytes, expected %d", len(data), 44+8)
+	}
+
+	fields := []struct {
+		name     string
+		offset   int
+		actual   uint32
+		expected uint32
+	}{
+		{"riff size", 4, binary.LittleEndian.Uint32(data[4:]), 36 + 8},
+		{"format", 20, uint32(binary.LittleEndian.Uint16(data[20:])), 1},
+		{"channels", 22, uint32(binary.LittleEndian.Uint16(data[22:])), 2},
+		{"sample rate", 24, binary.LittleEndian.Uint32(data[24:]), 22050},
+		{"byte rate", 28, binary.LittleEndian.Uint32(data[28:]), 22050 * 4},
+		{"block align", 32, uint32(binary.LittleEndian.Uint16(data[32:])), 4},
+		{"bits per sample", 34, uint32(binary.LittleEndian.Uint16(data[34:])), 16},
+		{"data size", 40, binary.LittleEndian.Uint32(data[40:]), 8},
+	}
+	for _, field := range fields {
+		if field.actual != field.expected {
+			t.Errorf("%s at %d is %d, expected %d", field.name, field.offset, field.actual, field.expected)
+		}
+	}
+	for _, tag := range []struct {
+		offset int
+		text   string
+	}{{0, "RIFF"}, {8, "WAVE"}, {12, "fmt "}, {36, "data"}} {
+		if actual := string(data[tag.offset : tag.offset+4]); actual != tag.text {
+			t.Errorf("tag at %d is %q, expected %q", tag.offset, actual, tag.text)
+		}
+	}
+	for index, sample := range rendered.Samples {
+		if actual := int16(binary.LittleEndian.Uint16(data[44+index*2:])); actual != sample {
+			t.Errorf("sample %d is %d, expected %d", index, actual, sample)
+		}
+	}
+	if expected := 2 * time.Second / 22050; rendered.Duration() != expected {
+		t.Errorf("duration is %v, expected %v", rendered.Duration(), expected)
+	}
+}
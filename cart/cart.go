@@ -0,0 +1,319 @@
+// Package cart reads and writes TIC-80 cartridges in the .tic chunk format.
+//
+// Each bank of a cartridge is loaded into the same memory views that [tic80.Sync] swaps into RAM,
+// so the data of a cartridge can be read and changed with the same methods that a game uses at runtime.
+package cart
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"unsafe"
+
+	"github.com/sorucoder/tic80"
+)
+
+// Cartridge Dimensions
+const (
+	BANK_COUNT        = 8
+	CODE_BANK_SIZE    = 0x10000
+	BINARY_BANK_COUNT = 4
+	BINARY_BANK_SIZE  = 0x10000
+)
+
+// ChunkType is an enumeration of the types of chunks in a cartridge.
+type ChunkType byte
+
+// Chunk Types
+const (
+	CHUNK_TILES         ChunkType = 1
+	CHUNK_SPRITES       ChunkType = 2
+	CHUNK_COVER         ChunkType = 3
+	CHUNK_MAP           ChunkType = 4
+	CHUNK_CODE          ChunkType = 5
+	CHUNK_FLAGS         ChunkType = 6
+	CHUNK_SOUND_EFFECTS ChunkType = 9
+	CHUNK_WAVEFORMS     ChunkType = 10
+	CHUNK_PALETTE       ChunkType = 12
+	CHUNK_PATTERNS_OLD  ChunkType = 13
+	CHUNK_MUSIC         ChunkType = 14
+	CHUNK_PATTERNS      ChunkType = 15
+	CHUNK_CODE_ZIP      ChunkType = 16
+	CHUNK_DEFAULT       ChunkType = 17
+	CHUNK_SCREEN        ChunkType = 18
+	CHUNK_BINARY        ChunkType = 19
+)
+
+// Chunk is a chunk of a cartridge that is not loaded into a [cart.Bank], such as a deprecated chunk.
+// It is kept as it was read, so that it is written back unchanged.
+type Chunk struct {
+	Type ChunkType
+	Bank int
+	Data []byte
+}
+
+// Bank is the data of one bank of a cartridge, as it is laid out in RAM.
+type Bank struct {
+	Tiles         tic80.TileMemory
+	Sprites       tic80.TileMemory
+	Map           tic80.MapMemory
+	Flags         tic80.SpriteFlagMemory
+	SoundEffects  tic80.SoundEffectMemory
+	Waveforms     tic80.WaveformMemory
+	MusicPatterns tic80.MusicPatternMemory
+	MusicTracks   tic80.MusicTrackMemory
+	Screen        tic80.ScreenMemory
+
+	// Palettes holds the palette of each [tic80.Layer].
+	Palettes [2]tic80.PaletteMemory
+}
+
+// Cart is a TIC-80 cartridge.
+type Cart struct {
+	Banks [BANK_COUNT]Bank
+
+	// Code is the source code of the cartridge, which is split across the banks when written.
+	Code string
+
+	// Binary is the compiled program of the cartridge, such as a WASM module, which is split across the banks when written.
+	Binary []byte
+
+	// Default is true if the default palette, waveforms and sound effects are loaded before the rest of the cartridge.
+	Default bool
+
+	// Chunks holds the chunks that are not loaded into the other fields.
+	Chunks []Chunk
+}
+
+// New constructs an empty [cart.Cart] that loads the defaults.
+func New() *Cart {
+	return &Cart{Default: true}
+}
+
+// view returns the memory of a value as a slice of bytes.
+func view[T any](value *T) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(value)), unsafe.Sizeof(*value))
+}
+
+// chunkTypes is the order in which the chunks of each bank are written.
+var chunkTypes = []ChunkType{
+	CHUNK_TILES,
+	CHUNK_SPRITES,
+	CHUNK_MAP,
+	CHUNK_FLAGS,
+	CHUNK_SOUND_EFFECTS,
+	CHUNK_WAVEFORMS,
+	CHUNK_PALETTE,
+	CHUNK_MUSIC,
+	CHUNK_PATTERNS,
+	CHUNK_SCREEN,
+}
+
+// chunk returns the memory that the chunk of the given type is loaded into, or nil if it is not loaded into a bank.
+func (bank *Bank) chunk(chunkType ChunkType) []byte {
+	switch chunkType {
+	case CHUNK_TILES:
+		return view(&bank.Tiles)
+	case CHUNK_SPRITES:
+		return view(&bank.Sprites)
+	case CHUNK_MAP:
+		return view(&bank.Map)
+	case CHUNK_FLAGS:
+		return view(&bank.Flags)
+	case CHUNK_SOUND_EFFECTS:
+		return view(&bank.SoundEffects)
+	case CHUNK_WAVEFORMS:
+		return view(&bank.Waveforms)
+	case CHUNK_PALETTE:
+		return view(&bank.Palettes)
+	case CHUNK_MUSIC:
+		return view(&bank.MusicTracks)
+	case CHUNK_PATTERNS:
+		return view(&bank.MusicPatterns)
+	case CHUNK_SCREEN:
+		return view(&bank.Screen)
+	}
+	return nil
+}
+
+// Parse parses a cartridge from the .tic format.
+func Parse(data []byte) (*Cart, error) {
+	cart := new(Cart)
+	var code, binary [BANK_COUNT][]byte
+	for offset := 0; offset < len(data); {
+		if len(data)-offset < 4 {
+			return nil, errors.New("cart: truncated chunk header")
+		}
+		chunkType := ChunkType(data[offset] & 0x1F)
+		bank := int(data[offset] >> 5)
+		size := int(data[offset+1]) | int(data[offset+2])<<8
+		offset += 4
+
+		if size == 0 && (chunkType == CHUNK_CODE || chunkType == CHUNK_BINARY) && offset < len(data) {
+			size = CODE_BANK_SIZE
+		}
+		if len(data)-offset < size {
+			return nil, fmt.Errorf("cart: truncated chunk of type %d in bank %d", chunkType, bank)
+		}
+		chunkData := data[offset : offset+size]
+		offset += size
+
+		switch chunkType {
+		case CHUNK_CODE:
+			code[bank] = chunkData
+		case CHUNK_BINARY:
+			binary[bank] = chunkData
+		case CHUNK_DEFAULT:
+			cart.Default = true
+		default:
+			memory := cart.Banks[bank].chunk(chunkType)
+			if memory == nil {
+				cart.Chunks = append(cart.Chunks, Chunk{chunkType, bank, append([]byte(nil), chunkData...)})
+				continue
+			}
+			if len(chunkData) > len(memory) {
+				return nil, fmt.Errorf("cart: chunk of type %d in bank %d is too large", chunkType, bank)
+			}
+			copy(memory, chunkData)
+		}
+	}
+	cart.Code = string(bytes.Join(code[:], nil))
+	cart.Binary = bytes.Join(binary[:], nil)
+	return cart, nil
+}
+
+// Read reads a cartridge in the .tic format.
+func Read(reader io.Reader) (*Cart, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// ReadFile reads a cartridge from a .tic file.
+func ReadFile(path string) (*Cart, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// appendHeader appends the header of a chunk to the data.
+func appendHeader(data []byte, chunkType ChunkType, bank, size int) []byte {
+	return append(data, byte(chunkType)&0x1F|byte(bank)<<5, byte(size), byte(size>>8), 0)
+}
+
+// appendChunk appends a chunk to the data, with its trailing zeroes trimmed as TIC-80 does.
+// Chunks that are entirely zero are left out.
+func appendChunk(data []byte, chunkType ChunkType, bank int, chunkData []byte) []byte {
+	chunkData = bytes.TrimRight(chunkData, "\x00")
+	if len(chunkData) == 0 {
+		return data
+	}
+	data = appendHeader(data, chunkType, bank, len(chunkData))
+	return append(data, chunkData...)
+}
+
+// appendSplit appends data that is split across the banks in chunks of the given size.
+// A chunk of exactly the given size is written with a size of 0, as TIC-80 does.
+func appendSplit(data []byte, chunkType ChunkType, split []byte, size int) []byte {
+	for bank := 0; len(split) > 0; bank++ {
+		chunkData := split
+		if len(chunkData) > size {
+			chunkData = chunkData[:size]
+		}
+		split = split[len(chunkData):]
+		data = appendHeader(data, chunkType, bank, len(chunkData))
+		data = append(data, chunkData...)
+	}
+	return data
+}
+
+// Bytes formats the cartridge in the .tic format.
+func (cart *Cart) Bytes() ([]byte, error) {
+	if len(cart.Code) > BANK_COUNT*CODE_BANK_SIZE {
+		return nil, errors.New("cart: code is too large")
+	}
+	if len(cart.Binary) > BINARY_BANK_COUNT*BINARY_BANK_SIZE {
+		return nil, errors.New("cart: binary is too large")
+	}
+
+	var data []byte
+	if cart.Default {
+		data = appendHeader(data, CHUNK_DEFAULT, 0, 0)
+	}
+	for bank := range cart.Banks {
+		for _, chunkType := range chunkTypes {
+			data = appendChunk(data, chunkType, bank, cart.Banks[bank].chunk(chunkType))
+		}
+	}
+	data = appendSplit(data, CHUNK_CODE, []byte(cart.Code), CODE_BANK_SIZE)
+	data = appendSplit(data, CHUNK_BINARY, cart.Binary, BINARY_BANK_SIZE)
+	for _, chunk := range cart.Chunks {
+		if len(chunk.Data) > 0xFFFF {
+			return nil, fmt.Errorf("cart: chunk of type %d in bank %d is too large", chunk.Type, chunk.Bank)
+		}
+		data = appendHeader(data, chunk.Type, chunk.Bank, len(chunk.Data))
+		data = append(data, chunk.Data...)
+	}
+	return data, nil
+}
+
+// Write writes the cartridge in the .tic format.
+func (cart *Cart) Write(writer io.Writer) error {
+	data, err := cart.Bytes()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
+	return err
+}
+
+// WriteFile writes the cartridge to a .tic file.
+func (cart *Cart) WriteFile(path string) error {
+	data, err := cart.Bytes()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load copies a bank of the cartridge into RAM, as TIC-80 does when the cartridge starts.
+// The palette of each [tic80.Layer] is loaded into its video bank.
+//
+// When the cartridge loads the defaults, a bank without a palette loads [tic80.SWEETIE_16],
+// and the waveforms and sound effects are loaded over the defaults of TIC-80.
+func (cart *Cart) Load(bank int) {
+	loaded := &cart.Banks[bank%BANK_COUNT]
+	*tic80.TILES = loaded.Tiles
+	*tic80.SPRITES = loaded.Sprites
+	*tic80.MAP = loaded.Map
+	*tic80.SPRITE_FLAGS = loaded.Flags
+	*tic80.SOUND_EFFECTS = loaded.SoundEffects
+	*tic80.WAVEFORMS = loaded.Waveforms
+	if cart.Default {
+		waveforms, soundEffects := defaults()
+		loadDefaults(view(tic80.WAVEFORMS), view(&waveforms))
+		loadDefaults(view(tic80.SOUND_EFFECTS), view(&soundEffects))
+	}
+	*tic80.MUSIC_PATTERNS = loaded.MusicPatterns
+	*tic80.MUSIC_TRACKS = loaded.MusicTracks
+
+	previous := tic80.Vbank(0)
+	for layer, palette := range loaded.Palettes {
+		tic80.Vbank(layer)
+		if palette == (tic80.PaletteMemory{}) && cart.Default {
+			defaultPalette, _ := tic80.ParsePalette(tic80.SWEETIE_16)
+			tic80.PALETTE.SetPalette(defaultPalette)
+		} else {
+			*tic80.PALETTE = palette
+		}
+	}
+	tic80.Vbank(0)
+	*tic80.SCREEN = loaded.Screen
+	tic80.Vbank(previous)
+}
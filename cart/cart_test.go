@@ -0,0 +1,160 @@
+package cart
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sorucoder/tic80"
+)
+
+// hello.tic is a small cartridge, laid out as TIC-80 writes it: the default chunk, a sprite in the tiles, a few tiles of the map,
+// the palette and the code in bank 0, a tile of the map in bank 1, and a cover image, which is kept as an unloaded chunk.
+func TestReadFile(t *testing.T) {
+	path := filepath.Join("testdata", "hello.tic")
+	cartridge, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !cartridge.Default {
+		t.Error("expected the cartridge to load the defaults")
+	}
+	if pixel := cartridge.Banks[0].Tiles.Tile(1).Pixel(7, 7); pixel != 1 {
+		t.Errorf("pixel of tile 1 is %d, expected 1", pixel)
+	}
+	for x, expected := range []int{0, 1, 2, 0} {
+		if tile := cartridge.Banks[0].Map.Tile(x, 0); tile != expected {
+			t.Errorf("tile %d of the map of bank 0 is %d, expected %d", x, tile, expected)
+		}
+	}
+	if tile := cartridge.Banks[1].Map.Tile(0, 0); tile != 5 {
+		t.Errorf("tile 0 of the map of bank 1 is %d, expected 5", tile)
+	}
+	sweetie, _ := tic80.ParsePalette(tic80.SWEETIE_16)
+	if palette := cartridge.Banks[0].Palettes[tic80.LAYER_BACKGROUND].Palette(); palette != sweetie {
+		t.Errorf("palette is %v, expected %v", palette, sweetie)
+	}
+	if cartridge.Code != "function TIC() cls(13) end" {
+		t.Errorf("code is %q", cartridge.Code)
+	}
+	if expected := []Chunk{{CHUNK_COVER, 0, []byte("GIF89a")}}; !reflect.DeepEqual(cartridge.Chunks, expected) {
+		t.Errorf("chunks are %v, expected %v", cartridge.Chunks, expected)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := cartridge.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(written, data) {
+		t.Errorf("wrote\n% x\nexpected\n% x", written, data)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	cartridge := New()
+	cartridge.Banks[0].Sprites.Tile(3).SetPixel(1, 2, 9)
+	cartridge.Banks[2].Flags.SetFlag(5, 3, true)
+	cartridge.Banks[3].SoundEffects.SoundEffect(4).SetVolume(0, 7)
+	cartridge.Banks[3].Waveforms.Waveform(2).GenerateSaw()
+	cartridge.Banks[4].MusicPatterns.Pattern(1).Row(2).SetStep(tic80.MusicStep{Note: tic80.NOTE_A, Octave: 4, SoundEffect: 3})
+	cartridge.Banks[4].MusicTracks.Track(0).SetFrame(0, [tic80.MUSIC_CHANNELS]int{1, 0, 0, 0})
+	cartridge.Banks[5].Screen.SetPixel(10, 10, 4)
+	cartridge.Banks[7].Palettes[tic80.LAYER_OVERLAY].SetColor(1, 0x12, 0x34, 0x56)
+	cartridge.Banks[7].Palettes[tic80.LAYER_OVERLAY][47] = 0xFF
+
+	// The code fills a whole bank exactly, which is written with a size of 0, and spills into the next.
+	cartridge.Code = strings.Repeat("-", CODE_BANK_SIZE) + "\nfunction TIC() end"
+	cartridge.Binary = bytes.Repeat([]byte{0x00, 0x61, 0x73, 0x6D}, BINARY_BANK_SIZE/4+1)
+	cartridge.Chunks = []Chunk{{CHUNK_PATTERNS_OLD, 6, []byte{1, 2, 3}}}
+
+	data, err := cartridge.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, cartridge) {
+		t.Error("the parsed cartridge differs from the written one")
+	}
+
+	path := filepath.Join(t.TempDir(), "round.tic")
+	if err := cartridge.WriteFile(path); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(read, cartridge) {
+		t.Error("the cartridge read from the file differs from the written one")
+	}
+}
+
+func TestChunkHeaders(t *testing.T) {
+	cartridge := new(Cart)
+	cartridge.Code = strings.Repeat("a", CODE_BANK_SIZE+1)
+	cartridge.Binary = []byte{1, 2}
+	cartridge.Banks[6].Map.SetTile(0, 0, 1)
+	data, err := cartridge.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type header struct {
+		chunkType ChunkType
+		bank      int
+		size      int
+	}
+	var headers []header
+	for offset := 0; offset < len(data); {
+		size := int(data[offset+1]) | int(data[offset+2])<<8
+		headers = append(headers, header{ChunkType(data[offset] & 0x1F), int(data[offset] >> 5), size})
+		if size == 0 {
+			size = CODE_BANK_SIZE
+		}
+		offset += 4 + size
+	}
+	expected := []header{
+		{CHUNK_MAP, 6, 1},
+		{CHUNK_CODE, 0, 0},
+		{CHUNK_CODE, 1, 1},
+		{CHUNK_BINARY, 0, 2},
+	}
+	if !reflect.DeepEqual(headers, expected) {
+		t.Errorf("headers are %v, expected %v", headers, expected)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{"header", []byte{byte(CHUNK_MAP), 1, 0}, "truncated chunk header"},
+		{"chunk", []byte{byte(CHUNK_MAP), 4, 0, 0, 1, 2}, "truncated chunk of type 4 in bank 0"},
+		{"too large", append([]byte{byte(CHUNK_FLAGS) | 2<<5, 0x01, 0x02, 0}, make([]byte, 0x201)...), "chunk of type 6 in bank 2 is too large"},
+	}
+	for _, test := range tests {
+		if _, err := Parse(test.data); err == nil || err.Error() != "cart: "+test.error {
+			t.Errorf("%s: error is %v, expected %q", test.name, err, "cart: "+test.error)
+		}
+	}
+
+	if _, err := (&Cart{Code: strings.Repeat("a", BANK_COUNT*CODE_BANK_SIZE+1)}).Bytes(); err == nil {
+		t.Error("expected an error for code that does not fit in the banks")
+	}
+	if _, err := (&Cart{Binary: make([]byte, BINARY_BANK_COUNT*BINARY_BANK_SIZE+1)}).Bytes(); err == nil {
+		t.Error("expected an error for a binary that does not fit in the banks")
+	}
+}
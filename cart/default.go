@@ -0,0 +1,45 @@
+package cart
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/sorucoder/tic80"
+)
+
+// defaultWaveforms are the waveforms that TIC-80 loads for a cartridge with the default chunk,
+// as one hexadecimal digit per sample: a square, a triangle and a saw wave.
+var defaultWaveforms = []string{
+	"00000000ffffffff00000000ffffffff",
+	"0123456789abcdeffedcba9876543210",
+	"0123456789abcdef0123456789abcdef",
+}
+
+// Default Sound Effect
+const (
+	defaultSoundEffectOctave = 3
+	defaultSoundEffectNote   = tic80.NOTE_E
+)
+
+// defaults returns the waveforms and sound effects that TIC-80 loads for a cartridge with the default chunk,
+// before the chunks of the cartridge are loaded over them.
+// The first sound effect plays the first waveform at full volume, and the rest are empty.
+func defaults() (waveforms tic80.WaveformMemory, soundEffects tic80.SoundEffectMemory) {
+	for id, samples := range defaultWaveforms {
+		for index, digit := range samples {
+			value, _ := strconv.ParseUint(string(digit), 16, 4)
+			waveforms.Waveform(id).SetSample(index, int(value))
+		}
+	}
+	effect := soundEffects.SoundEffect(0)
+	effect.SetOctave(defaultSoundEffectOctave)
+	effect.SetNote(defaultSoundEffectNote)
+	return
+}
+
+// loadDefaults copies the defaults over the memory after its last byte that is not zero,
+// as TIC-80 loads a chunk, with its trailing zeroes trimmed, over the defaults.
+func loadDefaults(memory, defaults []byte) {
+	loaded := len(bytes.TrimRight(memory, "\x00"))
+	copy(memory[loaded:], defaults[loaded:])
+}
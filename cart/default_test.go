@@ -0,0 +1,44 @@
+package cart
+
+import (
+	"testing"
+
+	"github.com/sorucoder/tic80"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	square := tic80.Waveform{0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF, 0xFF, 0xFF}
+	saw := tic80.Waveform{0x10, 0x32, 0x54, 0x76, 0x98, 0xBA, 0xDC, 0xFE, 0x10, 0x32, 0x54, 0x76, 0x98, 0xBA, 0xDC, 0xFE}
+	custom := tic80.Waveform{0x11, 15: 0x22}
+
+	// The chunk of the cartridge ends at its second waveform, so the third is loaded from the defaults.
+	cartridge := New()
+	*cartridge.Banks[0].Waveforms.Waveform(1) = custom
+	tic80.HostReset()
+	cartridge.Load(0)
+	if waveform := *tic80.WAVEFORMS.Waveform(0); waveform != (tic80.Waveform{}) {
+		t.Errorf("waveform 0 is %x, expected it to be loaded from the cartridge", waveform)
+	}
+	if waveform := *tic80.WAVEFORMS.Waveform(1); waveform != custom {
+		t.Errorf("waveform 1 is %x, expected %x", waveform, custom)
+	}
+	if waveform := *tic80.WAVEFORMS.Waveform(2); waveform != saw {
+		t.Errorf("waveform 2 is %x, expected %x", waveform, saw)
+	}
+
+	tic80.HostReset()
+	New().Load(0)
+	if waveform := *tic80.WAVEFORMS.Waveform(0); waveform != square {
+		t.Errorf("waveform 0 is %x, expected %x", waveform, square)
+	}
+	effect := tic80.SOUND_EFFECTS.SoundEffect(0)
+	if effect.Octave() != 3 || effect.Note() != tic80.NOTE_E || effect.Volume(0) != 0 || effect.Wave(0) != 0 {
+		t.Errorf("sound effect 0 is %x, expected E3 on waveform 0 at full volume", *effect)
+	}
+
+	tic80.HostReset()
+	new(Cart).Load(0)
+	if *tic80.WAVEFORMS != (tic80.WaveformMemory{}) || *tic80.SOUND_EFFECTS != (tic80.SoundEffectMemory{}) {
+		t.Error("a cartridge without the default chunk loaded the default waveforms or sound effects")
+	}
+}
@@ -0,0 +1,191 @@
+package tic80
+
+// SOUND_CHANNEL_SILENT_FRAMES is the default number of frames in a row that the registers of a channel must be silent
+// before [tic80.SoundChannels] finds the sound effect in it finished.
+const SOUND_CHANNEL_SILENT_FRAMES = 10
+
+// allocatedChannel is the state of a sound effect started by [tic80.SoundChannels].
+type allocatedChannel struct {
+	id         int
+	priority   int
+	started    int
+	duration   int
+	fadeFrames int
+	fadeLeft   int
+	silent     int
+}
+
+// SoundChannels allocates the sound channels to sound effects by priority, so that important sounds are not cut off.
+// It keeps track of the sound effects it starts, and a sound effect is finished once the registers of its channel
+// have been silent for a number of frames in a row, so that sound effects with gaps are not cut short,
+// or once its duration ends if it has one.
+//
+// [tic80.SoundChannels.Update] must be called every frame, from [tic80.Game.Tic].
+type SoundChannels struct {
+	channels     [4]allocatedChannel
+	allowed      [4]bool
+	silentFrames int
+	frame        int
+}
+
+// NewSoundChannels constructs a [tic80.SoundChannels] object that allocates all four channels.
+func NewSoundChannels() *SoundChannels {
+	manager := &SoundChannels{silentFrames: SOUND_CHANNEL_SILENT_FRAMES}
+	for channel := range manager.channels {
+		manager.channels[channel].id = -1
+		manager.allowed[channel] = true
+	}
+	return manager
+}
+
+// SetChannels sets the channels that may be allocated, such as those that the music does not use.
+func (manager *SoundChannels) SetChannels(channels ...int) *SoundChannels {
+	manager.allowed = [4]bool{}
+	for _, channel := range channels {
+		manager.allowed[channel%4] = true
+	}
+	return manager
+}
+
+// SetSilentFrames sets the number of frames in a row that the registers of a channel must be silent
+// before the sound effect in it is finished, for sound effects without a duration.
+// It should be longer than the longest gap in the volume envelopes of the sound effects that are played.
+func (manager *SoundChannels) SetSilentFrames(frames int) *SoundChannels {
+	manager.silentFrames = maximum(frames, 1)
+	return manager
+}
+
+// sounding returns true if the registers of a channel are not silent.
+func sounding(channel int) bool {
+	register := SOUND_REGISTERS.Channel(channel)
+	return register.Volume() > 0 && register.Frequency() > 0
+}
+
+// Play plays a sound effect in a free channel, or else in the channel playing the sound with the lowest priority,
+// as long as that priority is no higher than its own. The channel of the options is ignored.
+// It returns the channel the sound effect is played in, or -1 if every channel is busy with a more important sound.
+func (manager *SoundChannels) Play(options *SoundEffectOptions, priority int) int {
+	if options == nil {
+		options = &defaultSoundEffectOptions
+	}
+
+	// Free channels come first, then channels with sound that was not started here, such as music,
+	// then the channel with the lowest priority that started the longest ago.
+	chosen := -1
+	var chosenRank [3]int
+	for channel, allocated := range manager.channels {
+		if !manager.allowed[channel] {
+			continue
+		}
+		var rank [3]int
+		switch {
+		case allocated.id < 0 && !sounding(channel):
+			rank = [3]int{0, 0, 0}
+		case allocated.id < 0:
+			rank = [3]int{1, 0, 0}
+		case allocated.priority <= priority:
+			rank = [3]int{2, allocated.priority, allocated.started}
+		default:
+			continue
+		}
+		if chosen < 0 || rank[0] < chosenRank[0] ||
+			rank[0] == chosenRank[0] && (rank[1] < chosenRank[1] || rank[1] == chosenRank[1] && rank[2] < chosenRank[2]) {
+			chosen, chosenRank = channel, rank
+		}
+	}
+	if chosen < 0 {
+		return -1
+	}
+
+	playing := *options
+	playing.channel = chosen
+	Sfx(&playing)
+	manager.channels[chosen] = allocatedChannel{
+		id:       playing.id,
+		priority: priority,
+		started:  manager.frame,
+		duration: playing.duration,
+	}
+	return chosen
+}
+
+// Stop stops the sound effect playing in a channel.
+func (manager *SoundChannels) Stop(channel int) {
+	channel %= 4
+	Sfx(NewSoundEffectOptions().SetChannel(channel))
+	*SOUND_REGISTERS.Channel(channel) = SoundRegister{}
+	manager.channels[channel] = allocatedChannel{id: -1}
+}
+
+// StopAll stops the sound effects playing in every channel that may be allocated.
+func (manager *SoundChannels) StopAll() {
+	for channel, allowed := range manager.allowed {
+		if allowed {
+			manager.Stop(channel)
+		}
+	}
+}
+
+// Fade fades out the sound effect playing in a channel over a number of frames, then stops it.
+func (manager *SoundChannels) Fade(channel, frames int) {
+	channel %= 4
+	if manager.channels[channel].id < 0 {
+		return
+	}
+	if frames <= 0 {
+		manager.Stop(channel)
+		return
+	}
+	manager.channels[channel].fadeFrames = frames
+	manager.channels[channel].fadeLeft = frames
+}
+
+// SoundEffect returns the id of the sound effect playing in a channel, or -1 if none that was started here is.
+// The id only comes from the sound effects started with [tic80.SoundChannels.Play],
+// so it is -1 for sound effects started with [tic80.Sfx] or by the music, even if they are playing.
+func (manager *SoundChannels) SoundEffect(channel int) int {
+	return manager.channels[channel%4].id
+}
+
+// Busy returns true if a channel is playing a sound effect that was started here, or any other sound such as music;
+// false otherwise.
+func (manager *SoundChannels) Busy(channel int) bool {
+	channel %= 4
+	return manager.channels[channel].id >= 0 || sounding(channel)
+}
+
+// Update checks which sound effects have finished, and fades the sound effects that are fading.
+func (manager *SoundChannels) Update() {
+	for channel := range manager.channels {
+		allocated := &manager.channels[channel]
+		if allocated.id < 0 {
+			continue
+		}
+
+		// The registers are only written at the start of the frame after a sound effect is played.
+		if allocated.started < manager.frame {
+			if sounding(channel) {
+				allocated.silent = 0
+			} else {
+				allocated.silent++
+			}
+			elapsed := manager.frame - allocated.started
+			if allocated.duration > 0 && elapsed >= allocated.duration ||
+				allocated.duration <= 0 && allocated.silent >= manager.silentFrames {
+				*allocated = allocatedChannel{id: -1}
+				continue
+			}
+		}
+
+		if allocated.fadeFrames > 0 {
+			if allocated.fadeLeft <= 0 {
+				manager.Stop(channel)
+				continue
+			}
+			register := SOUND_REGISTERS.Channel(channel)
+			register.SetVolume(register.Volume() * allocated.fadeLeft / allocated.fadeFrames)
+			allocated.fadeLeft--
+		}
+	}
+	manager.frame++
+}
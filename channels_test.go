@@ -0,0 +1,46 @@
+//go:build !tinygo
+
+package tic80
+
+import "testing"
+
+func TestSoundChannelsSilence(t *testing.T) {
+	HostReset()
+	manager := NewSoundChannels().SetChannels(0).SetSilentFrames(3)
+	if channel := manager.Play(NewSoundEffectOptions().SetId(1), 10); channel != 0 {
+		t.Fatalf("played in channel %d, expected 0", channel)
+	}
+	manager.Update()
+
+	register := SOUND_REGISTERS.Channel(0)
+	frames := []struct {
+		sounding bool
+		id       int
+	}{
+		{false, 1},
+		{false, 1},
+		{true, 1},
+		{false, 1},
+		{false, 1},
+		{false, -1},
+	}
+	for frame, expected := range frames {
+		*register = SoundRegister{}
+		if expected.sounding {
+			register.SetFrequency(440)
+			register.SetVolume(15)
+		}
+		manager.Update()
+		if id := manager.SoundEffect(0); id != expected.id {
+			t.Fatalf("frame %d: sound effect is %d, expected %d", frame, id, expected.id)
+		}
+		if expected.id >= 0 {
+			if channel := manager.Play(NewSoundEffectOptions().SetId(2), 0); channel != -1 {
+				t.Fatalf("frame %d: a less important sound effect took channel %d", frame, channel)
+			}
+		}
+	}
+	if channel := manager.Play(NewSoundEffectOptions().SetId(2), 0); channel != 0 {
+		t.Errorf("played in channel %d after the sound effect finished, expected 0", channel)
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// target is the TinyGo target for TIC-80.
+// Memory is imported from TIC-80, and the Go heap starts at [tic80.FREE_RAM].
+//
+//go:embed target.json
+var target []byte
+
+// buildFlags are the flags passed to TinyGo besides the target, chosen so that builds are reproducible.
+var buildFlags = []string{"-no-debug", "-opt=z", "-panic=trap"}
+
+func runBuild(arguments []string) error {
+	flags := flag.NewFlagSet("build", flag.ContinueOnError)
+	output := flags.String("o", "game.wasm", "the WASM `file` to write")
+	cartPath := flags.String("cart", "", "the cartridge `file` to pack the WASM binary into, if any")
+	tinygo := flags.String("tinygo", "tinygo", "the TinyGo `command` to run")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() > 1 {
+		return errors.New("too many arguments")
+	}
+	pkg := "."
+	if flags.NArg() == 1 {
+		pkg = flags.Arg(0)
+	}
+
+	directory, err := os.MkdirTemp("", "tic80")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(directory)
+	targetPath := filepath.Join(directory, "tic80.json")
+	if err := os.WriteFile(targetPath, target, 0o644); err != nil {
+		return err
+	}
+
+	tinygoArguments := append([]string{"build", "-target", targetPath, "-o", *output}, buildFlags...)
+	tinygoArguments = append(tinygoArguments, pkg)
+	command := exec.Command(*tinygo, tinygoArguments...)
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return err
+	}
+
+	if *cartPath != "" {
+		return pack(*cartPath, *output, *cartPath)
+	}
+	return nil
+}
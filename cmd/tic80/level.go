@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+
+	"github.com/sorucoder/tic80/cart"
+	"github.com/sorucoder/tic80/level"
+)
+
+func runLevel(arguments []string) error {
+	flags := flag.NewFlagSet("level", flag.ContinueOnError)
+	levelName := flags.String("level", "", "the `name` of the level to import from an LDtk project (default: the first level)")
+	layerName := flags.String("layer", "", "the `name` of the tile layer to import (default: the first tile layer)")
+	output := flags.String("o", "", "the Go `file` to write the objects to, if any")
+	packageName := flags.String("package", "main", "the `package` of the Go file")
+	bankIndex := flags.Int("bank", 0, "the `bank` of the cartridge to import into")
+	x := flags.Int("x", 0, "the map `column` to place the level at")
+	y := flags.Int("y", 0, "the map `row` to place the level at")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("expected a cartridge and a Tiled map or LDtk project")
+	}
+	if *bankIndex < 0 || *bankIndex >= cart.BANK_COUNT {
+		return errors.New("bank must be from 0 to 7")
+	}
+	cartPath, levelPath := flags.Arg(0), flags.Arg(1)
+
+	cartridge, err := cart.ReadFile(cartPath)
+	if err != nil {
+		return err
+	}
+	bank := &cartridge.Banks[*bankIndex]
+
+	loaded, err := level.Load(levelPath, level.NewLoadOptions().SetLevel(*levelName).SetLayer(*layerName))
+	if err != nil {
+		return err
+	}
+	if err := loaded.Apply(&bank.Map, &bank.Flags, *x, *y); err != nil {
+		return err
+	}
+
+	if *output != "" {
+		objects, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		if err := level.WriteObjects(objects, *packageName, loaded); err != nil {
+			objects.Close()
+			return err
+		}
+		if err := objects.Close(); err != nil {
+			return err
+		}
+	}
+	return cartridge.WriteFile(cartPath)
+}
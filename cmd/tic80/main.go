@@ -0,0 +1,61 @@
+// Command tic80 builds Go games into TIC-80 cartridges.
+//
+// Usage:
+//
+//	tic80 build [-o game.wasm] [-cart game.tic] [-tinygo tinygo] [package]
+//	tic80 pack [-o output.tic] game.tic game.wasm
+//	tic80 new [-module path] directory
+//	tic80 sprites [-regions file] [-o sprites.go] [-sprites] [-dither] game.tic sheet.png
+//	tic80 level [-level name] [-layer name] [-o level.go] [-x column] [-y row] game.tic level.tmx
+//	tic80 midi [-track id] [-channels list] [-instruments list] [-speed speed] [-tempo tempo] game.tic song.mid
+//	tic80 wav [-o sound.wav] [-sfx id | -music track] [-frames count] [-failsilent] game.tic
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// command is a subcommand of the tool.
+type command struct {
+	name    string
+	summary string
+	run     func(arguments []string) error
+}
+
+var commands = []command{
+	{"build", "compile a Go package to WASM with TinyGo, and optionally pack it into a cartridge", runBuild},
+	{"pack", "put a WASM binary into a cartridge, keeping its assets", runPack},
+	{"new", "create a new game that uses this library", runNew},
+	{"sprites", "import a PNG sheet into the tiles or sprites of a cartridge, and write their ids to a Go file", runSprites},
+	{"level", "import a Tiled map or LDtk level into the map of a cartridge, and write its objects to a Go file", runLevel},
+	{"midi", "convert a MIDI file into a music track of a cartridge", runMIDI},
+	{"wav", "render a sound effect or music track of a cartridge to a WAV file", runWAV},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: tic80 <command> [arguments]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, command := range commands {
+		fmt.Fprintf(os.Stderr, "  %-8s %s\n", command.name, command.summary)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	for _, command := range commands {
+		if command.name == os.Args[1] {
+			if err := command.run(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "tic80 %s: %v\n", command.name, err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+	usage()
+	os.Exit(2)
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/sorucoder/tic80/cart"
+	"github.com/sorucoder/tic80/midi"
+)
+
+func runMIDI(arguments []string) error {
+	flags := flag.NewFlagSet("midi", flag.ContinueOnError)
+	trackIndex := flags.Int("track", 0, "the `track` of the cartridge to write, from 0 to 7")
+	bankIndex := flags.Int("bank", 0, "the `bank` of the cartridge to write to")
+	channels := flags.String("channels", "", "the comma-separated MIDI `channels`, from 0 to 15, to convert into each music channel (default: the first four to play a note)")
+	instruments := flags.String("instruments", "", "comma-separated `program=sfx` pairs that set the sound effect that plays each MIDI program")
+	soundEffect := flags.Int("sfx", 0, "the `id` of the sound effect that plays the programs without an instrument")
+	speed := flags.Int("speed", 6, "the `speed` of the track, which sets how many rows each beat is quantized to")
+	tempo := flags.Int("tempo", -1, "the `tempo` of the track in beats per minute (default: the first tempo of the MIDI file)")
+	firstPattern := flags.Int("first", 1, "the `id` of the first pattern that may be written to, from 1 to 60")
+	noVolume := flags.Bool("novolume", false, "do not set the volume from the velocity of notes")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("expected a cartridge and a MIDI file")
+	}
+	if *bankIndex < 0 || *bankIndex >= cart.BANK_COUNT {
+		return errors.New("bank must be from 0 to 7")
+	}
+	cartPath, midiPath := flags.Arg(0), flags.Arg(1)
+
+	options := midi.NewConvertOptions().SetSoundEffect(*soundEffect).SetSpeed(*speed).SetTempo(*tempo).SetFirstPattern(*firstPattern)
+	if *noVolume {
+		options.ToggleVolume()
+	}
+	if *channels != "" {
+		var parsed []int
+		for _, field := range strings.Split(*channels, ",") {
+			channel, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				return fmt.Errorf("%q is not a valid channel", field)
+			}
+			parsed = append(parsed, channel)
+		}
+		options.SetChannels(parsed...)
+	}
+	if *instruments != "" {
+		for _, pair := range strings.Split(*instruments, ",") {
+			program, id, found := strings.Cut(pair, "=")
+			programNumber, programErr := strconv.Atoi(strings.TrimSpace(program))
+			idNumber, idErr := strconv.Atoi(strings.TrimSpace(id))
+			if !found || programErr != nil || idErr != nil {
+				return fmt.Errorf("%q is not a valid program=sfx pair", pair)
+			}
+			options.SetInstrument(programNumber, idNumber)
+		}
+	}
+
+	cartridge, err := cart.ReadFile(cartPath)
+	if err != nil {
+		return err
+	}
+	bank := &cartridge.Banks[*bankIndex]
+
+	file, err := os.Open(midiPath)
+	if err != nil {
+		return err
+	}
+	song, err := midi.Parse(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	warnings, err := midi.Convert(song, bank.MusicTracks.Track(*trackIndex), &bank.MusicPatterns, options)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "tic80 midi: warning: %s\n", warning)
+	}
+	if err != nil {
+		return err
+	}
+	return cartridge.WriteFile(cartPath)
+}
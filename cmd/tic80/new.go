@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+
+	"github.com/sorucoder/tic80/cart"
+)
+
+const libraryPath = "github.com/sorucoder/tic80"
+
+const mainTemplate = `package main
+
+import "github.com/sorucoder/tic80"
+
+type game struct {
+	tic80.BaseGame
+	x, y int
+}
+
+func (g *game) Boot() {
+	g.x, g.y = 96, 24
+}
+
+func (g *game) Tic() {
+	if tic80.Btn(tic80.GAMEPAD_1 + tic80.BUTTON_UP) {
+		g.y--
+	}
+	if tic80.Btn(tic80.GAMEPAD_1 + tic80.BUTTON_DOWN) {
+		g.y++
+	}
+	if tic80.Btn(tic80.GAMEPAD_1 + tic80.BUTTON_LEFT) {
+		g.x--
+	}
+	if tic80.Btn(tic80.GAMEPAD_1 + tic80.BUTTON_RIGHT) {
+		g.x++
+	}
+
+	tic80.Cls(13)
+	tic80.Print("HELLO WORLD FROM GO!", 65, 84, nil)
+	tic80.Rect(g.x, g.y, 8, 8, 12)
+}
+
+func main() {
+	tic80.Run(&game{})
+}
+`
+
+// libraryVersion returns the version of this library that the tool was built from, or "" if it is not known.
+func libraryVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok || info.Main.Path != libraryPath || info.Main.Version == "" || info.Main.Version == "(devel)" {
+		return ""
+	}
+	return info.Main.Version
+}
+
+func runNew(arguments []string) error {
+	flags := flag.NewFlagSet("new", flag.ContinueOnError)
+	module := flags.String("module", "", "the module `path` of the game (default: the name of the directory)")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected a directory")
+	}
+	directory := flags.Arg(0)
+	if *module == "" {
+		*module = filepath.Base(directory)
+	}
+
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return err
+	}
+	for _, name := range []string{"go.mod", "main.go", "game.tic"} {
+		if _, err := os.Stat(filepath.Join(directory, name)); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(directory, name))
+		}
+	}
+
+	goMod := fmt.Sprintf("module %s\n\ngo 1.19\n", *module)
+	version := libraryVersion()
+	if version != "" {
+		goMod += fmt.Sprintf("\nrequire %s %s\n", libraryPath, version)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "go.mod"), []byte(goMod), 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(directory, "main.go"), []byte(mainTemplate), 0o644); err != nil {
+		return err
+	}
+
+	cartridge := cart.New()
+	cartridge.Code = wasmCode
+	if err := cartridge.WriteFile(filepath.Join(directory, "game.tic")); err != nil {
+		return err
+	}
+
+	if version == "" {
+		fmt.Printf("run \"go get %s\" in %s to add this library to the game\n", libraryPath, directory)
+	}
+	return nil
+}
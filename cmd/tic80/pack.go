@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"strings"
+
+	"github.com/sorucoder/tic80/cart"
+)
+
+// wasmCode is the code of a cartridge that runs its binary as WASM.
+const wasmCode = "-- script: wasm\n"
+
+func runPack(arguments []string) error {
+	flags := flag.NewFlagSet("pack", flag.ContinueOnError)
+	output := flags.String("o", "", "the cartridge `file` to write, instead of overwriting the input")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("expected a cartridge and a WASM binary")
+	}
+	cartPath, wasmPath := flags.Arg(0), flags.Arg(1)
+	if *output == "" {
+		*output = cartPath
+	}
+	return pack(cartPath, wasmPath, *output)
+}
+
+// pack reads the cartridge, replaces its binary with the WASM binary, and writes it to the output.
+// Everything else in the cartridge is kept. If the cartridge does not exist, a new one is made.
+func pack(cartPath, wasmPath, output string) error {
+	binary, err := os.ReadFile(wasmPath)
+	if err != nil {
+		return err
+	}
+
+	cartridge, err := cart.ReadFile(cartPath)
+	if errors.Is(err, os.ErrNotExist) {
+		cartridge = cart.New()
+	} else if err != nil {
+		return err
+	}
+
+	cartridge.Binary = binary
+	if !strings.Contains(cartridge.Code, "script: wasm") {
+		cartridge.Code = wasmCode
+	}
+	return cartridge.WriteFile(output)
+}
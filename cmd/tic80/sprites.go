@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"image"
+	_ "image/png"
+	"os"
+
+	"github.com/sorucoder/tic80"
+	"github.com/sorucoder/tic80/cart"
+	"github.com/sorucoder/tic80/spritesheet"
+)
+
+func runSprites(arguments []string) error {
+	flags := flag.NewFlagSet("sprites", flag.ContinueOnError)
+	regionsPath := flags.String("regions", "", "the `file` of named regions, one \"name x y width height\" per line in tiles (default: every tile that is not empty)")
+	output := flags.String("o", "sprites.go", "the Go `file` to write the sprite constants to")
+	packageName := flags.String("package", "main", "the `package` of the Go file")
+	bankIndex := flags.Int("bank", 0, "the `bank` of the cartridge to import into")
+	sprites := flags.Bool("sprites", false, "import into the sprites instead of the tiles")
+	first := flags.Int("first", 0, "the `index` of the first tile of the bank that may be written to")
+	dither := flags.Bool("dither", false, "dither colors that are not in the palette")
+	transparentColor := flags.Int("transparent", 0, "the `color` to use for transparent pixels")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() != 2 {
+		return errors.New("expected a cartridge and a PNG sheet")
+	}
+	if *bankIndex < 0 || *bankIndex >= cart.BANK_COUNT {
+		return errors.New("bank must be from 0 to 7")
+	}
+	cartPath, sheetPath := flags.Arg(0), flags.Arg(1)
+
+	cartridge, err := cart.ReadFile(cartPath)
+	if err != nil {
+		return err
+	}
+	bank := &cartridge.Banks[*bankIndex]
+
+	file, err := os.Open(sheetPath)
+	if err != nil {
+		return err
+	}
+	picture, _, err := image.Decode(file)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	palette := bank.Palettes[tic80.LAYER_BACKGROUND].Palette()
+	if bank.Palettes[tic80.LAYER_BACKGROUND] == (tic80.PaletteMemory{}) {
+		palette, _ = tic80.ParsePalette(tic80.SWEETIE_16)
+	}
+	sheet := spritesheet.Quantize(picture, palette, *dither, *transparentColor)
+
+	var regions []spritesheet.Region
+	if *regionsPath != "" {
+		file, err := os.Open(*regionsPath)
+		if err != nil {
+			return err
+		}
+		regions, err = spritesheet.ParseRegions(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	} else {
+		regions = spritesheet.GridRegions(sheet, *transparentColor)
+	}
+
+	options := spritesheet.NewImportOptions().SetFirst(*first)
+	tiles := &bank.Tiles
+	if *sprites {
+		options.ToggleSprites()
+		tiles = &bank.Sprites
+	}
+	imported, err := spritesheet.Import(tiles, sheet, regions, options)
+	if err != nil {
+		return err
+	}
+
+	constants, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := spritesheet.WriteConstants(constants, *packageName, imported); err != nil {
+		constants.Close()
+		return err
+	}
+	if err := constants.Close(); err != nil {
+		return err
+	}
+	return cartridge.WriteFile(cartPath)
+}
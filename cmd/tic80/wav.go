@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"flag"
+
+	"github.com/sorucoder/tic80"
+	"github.com/sorucoder/tic80/audio"
+	"github.com/sorucoder/tic80/cart"
+)
+
+func runWAV(arguments []string) error {
+	flags := flag.NewFlagSet("wav", flag.ContinueOnError)
+	output := flags.String("o", "sound.wav", "the `file` to write")
+	bankIndex := flags.Int("bank", 0, "the `bank` of the cartridge to play")
+	soundEffect := flags.Int("sfx", -1, "the `id` of the sound effect to render")
+	track := flags.Int("music", -1, "the `track` to render")
+	note := flags.Int("note", -1, "the `note` to play the sound effect at, from 0 (C) to 11 (B) (default: the note of the sound effect)")
+	octave := flags.Int("octave", 4, "the `octave` to play the sound effect at, when a note is set")
+	duration := flags.Int("duration", -1, "the `duration` in frames to play the sound effect (default: until it ends)")
+	speed := flags.Int("speed", 0, "the `speed` of the sound effect, or of the music track when set (default: the speed of the music track)")
+	volume := flags.Int("volume", 15, "the `volume` of the sound effect, from 0 to 15")
+	tempo := flags.Int("tempo", -1, "the `tempo` of the music track in beats per minute (default: the tempo of the track)")
+	loop := flags.Bool("loop", false, "loop the music track until the maximum number of frames")
+	frames := flags.Int("frames", 60*60, "the maximum number of `frames` to render")
+	failSilent := flags.Bool("failsilent", false, "fail if the rendered sound is silent")
+	if err := flags.Parse(arguments); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return errors.New("expected a cartridge")
+	}
+	if (*soundEffect < 0) == (*track < 0) {
+		return errors.New("expected either -sfx or -music")
+	}
+	if *bankIndex < 0 || *bankIndex >= cart.BANK_COUNT {
+		return errors.New("bank must be from 0 to 7")
+	}
+
+	cartridge, err := cart.ReadFile(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var rendered *audio.Audio
+	if *soundEffect >= 0 {
+		options := tic80.NewSoundEffectOptions().SetId(*soundEffect).SetDuration(*duration).SetSpeed(*speed).SetVolume(*volume)
+		if *note >= 0 {
+			options.SetNote(tic80.SoundEffectNote(*note), *octave)
+		}
+		rendered = audio.RenderSoundEffect(cartridge, *bankIndex, options, *frames)
+	} else {
+		options := tic80.NewMusicOptions().SetTrack(*track)
+		if !*loop {
+			options.ToggleLooping()
+		}
+		// The setters wrap their arguments into range starting from the lowest tempo and speed,
+		// so the flags are clamped into range and given relative to those.
+		if *tempo >= 0 {
+			options.SetTempo(clamp(*tempo, 40, 250) - 40)
+		}
+		flags.Visit(func(set *flag.Flag) {
+			if set.Name == "speed" {
+				options.SetSpeed(clamp(*speed, 1, 31) - 1)
+			}
+		})
+		rendered = audio.RenderMusic(cartridge, *bankIndex, options, *frames)
+	}
+
+	if *failSilent && rendered.Silent() {
+		return errors.New("the rendered sound is silent")
+	}
+	return rendered.WriteWAVFile(*output)
+}
+
+// clamp returns the value limited to the range from lowest to highest.
+func clamp(value, lowest, highest int) int {
+	if value < lowest {
+		return lowest
+	}
+	if value > highest {
+		return highest
+	}
+	return value
+}
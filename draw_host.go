@@ -0,0 +1,432 @@
+//go:build !tinygo
+
+package tic80
+
+import (
+	"math"
+	"unsafe"
+)
+
+// plot draws a pixel of an already mapped color to the screen, unless it is outside of the clipping region.
+func plot(x, y, color int) {
+	if x >= host.clipLeft && x < host.clipRight && y >= host.clipTop && y < host.clipBottom {
+		SCREEN.SetPixel(x, y, color)
+	}
+}
+
+// plotSpan draws a horizontal span of pixels of an already mapped color to the screen.
+func plotSpan(x0, x1, y, color int) {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	for x := x0; x <= x1; x++ {
+		plot(x, y, color)
+	}
+}
+
+// plotBlock draws a square of pixels of an already mapped color to the screen.
+func plotBlock(x, y, size, color int) {
+	for blockY := y; blockY < y+size; blockY++ {
+		for blockX := x; blockX < x+size; blockX++ {
+			plot(blockX, blockY, color)
+		}
+	}
+}
+
+// mapColor returns the color that the given color is drawn as, according to [tic80.PALETTE_MAP].
+func mapColor(color int8) int {
+	return PALETTE_MAP.Color(int(color) & 0x0F)
+}
+
+// transparentColors returns which colors of the given buffer prepared by toByteData are transparent.
+func transparentColors(buffer unsafe.Pointer, count int8) (transparent [16]bool) {
+	for _, color := range fromByteData(buffer, count) {
+		transparent[color%16] = true
+	}
+	return
+}
+
+// sheetTile returns the specified tile of the tiles followed by the sprites.
+func sheetTile(id int) *Tile {
+	id = wrap(id, 512)
+	if id < 256 {
+		return TILES.Tile(id)
+	}
+	return SPRITES.Tile(id - 256)
+}
+
+func rawClip(x, y, width, height int32) {
+	host.clipLeft = maximum(int(x), 0)
+	host.clipTop = maximum(int(y), 0)
+	host.clipRight = minimum(int(x+width), SCREEN_WIDTH)
+	host.clipBottom = minimum(int(y+height), SCREEN_HEIGHT)
+}
+
+func rawCls(color int8) {
+	if host.clipLeft == 0 && host.clipTop == 0 && host.clipRight == SCREEN_WIDTH && host.clipBottom == SCREEN_HEIGHT {
+		value := byte(color)&0x0F | byte(color)<<4
+		for index := range SCREEN {
+			SCREEN[index] = value
+		}
+		return
+	}
+	rawRect(int32(host.clipLeft), int32(host.clipTop), int32(host.clipRight-host.clipLeft), int32(host.clipBottom-host.clipTop), color)
+}
+
+func rawPix(x, y int32, color int8) uint8 {
+	previous := SCREEN.Pixel(int(x), int(y))
+	if color >= 0 {
+		plot(int(x), int(y), mapColor(color))
+	}
+	return uint8(previous)
+}
+
+func rawRect(x, y, width, height int32, color int8) {
+	mapped := mapColor(color)
+	for rectY := y; rectY < y+height; rectY++ {
+		for rectX := x; rectX < x+width; rectX++ {
+			plot(int(rectX), int(rectY), mapped)
+		}
+	}
+}
+
+func rawRectb(x, y, width, height int32, color int8) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	mapped := mapColor(color)
+	right, bottom := int(x+width-1), int(y+height-1)
+	plotSpan(int(x), right, int(y), mapped)
+	plotSpan(int(x), right, bottom, mapped)
+	for rectY := int(y) + 1; rectY < bottom; rectY++ {
+		plot(int(x), rectY, mapped)
+		plot(right, rectY, mapped)
+	}
+}
+
+// plotEllipse draws an ellipse of an already mapped color to the screen, either filled or as a border.
+func plotEllipse(centerX, centerY, radiusX, radiusY, color int, filled bool) {
+	if radiusX < 0 || radiusY < 0 {
+		return
+	}
+	plotQuadrants := func(x, y int) {
+		if filled {
+			plotSpan(centerX-x, centerX+x, centerY+y, color)
+			plotSpan(centerX-x, centerX+x, centerY-y, color)
+		} else {
+			plot(centerX-x, centerY+y, color)
+			plot(centerX+x, centerY+y, color)
+			plot(centerX+x, centerY-y, color)
+			plot(centerX-x, centerY-y, color)
+		}
+	}
+
+	// See http://members.chello.at/easyfilter/bresenham.html.
+	x, y := -radiusX, 0
+	squaredX, squaredY := radiusX*radiusX, radiusY*radiusY
+	err := x*(2*squaredY+x) + squaredY
+	for x <= 0 {
+		plotQuadrants(-x, y)
+		doubled := 2 * err
+		if doubled >= (x*2+1)*squaredY {
+			x++
+			err += (x*2 + 1) * squaredY
+		}
+		if doubled <= (y*2+1)*squaredX {
+			y++
+			err += (y*2 + 1) * squaredX
+		}
+	}
+	for y++; y <= radiusY; y++ {
+		plotQuadrants(0, y)
+	}
+}
+
+func rawCirc(x, y, radius int32, color int8) {
+	plotEllipse(int(x), int(y), int(radius), int(radius), mapColor(color), true)
+}
+
+func rawCircb(x, y, radius int32, color int8) {
+	plotEllipse(int(x), int(y), int(radius), int(radius), mapColor(color), false)
+}
+
+func rawElli(x, y, radiusX, radiusY int32, color int8) {
+	plotEllipse(int(x), int(y), int(radiusX), int(radiusY), mapColor(color), true)
+}
+
+func rawEllib(x, y, radiusX, radiusY int32, color int8) {
+	plotEllipse(int(x), int(y), int(radiusX), int(radiusY), mapColor(color), false)
+}
+
+// plotLine draws a line of an already mapped color to the screen.
+func plotLine(x0, y0, x1, y1, color int) {
+	deltaX, deltaY := x1-x0, -(y1 - y0)
+	stepX, stepY := 1, 1
+	if deltaX < 0 {
+		deltaX, stepX = -deltaX, -1
+	}
+	if deltaY > 0 {
+		deltaY, stepY = -deltaY, -1
+	}
+	err := deltaX + deltaY
+	for {
+		plot(x0, y0, color)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		doubled := 2 * err
+		if doubled >= deltaY {
+			err += deltaY
+			x0 += stepX
+		}
+		if doubled <= deltaX {
+			err += deltaX
+			y0 += stepY
+		}
+	}
+}
+
+// round rounds a coordinate to the nearest pixel.
+func round(value float32) int {
+	return int(math.Floor(float64(value) + 0.5))
+}
+
+func rawLine(x0, y0, x1, y1 float32, color int8) {
+	plotLine(round(x0), round(y0), round(x1), round(y1), mapColor(color))
+}
+
+// edge returns which side of the edge from (x0, y0) to (x1, y1) the point (x, y) is on.
+func edge(x0, y0, x1, y1, x, y float32) float32 {
+	return (x1-x0)*(y-y0) - (y1-y0)*(x-x0)
+}
+
+// rasterizeTriangle calls shade for each pixel whose center is within the triangle, with its barycentric weights.
+func rasterizeTriangle(x0, y0, x1, y1, x2, y2 float32, shade func(x, y int, weight0, weight1, weight2 float32)) {
+	area := edge(x0, y0, x1, y1, x2, y2)
+	if area == 0 {
+		return
+	}
+
+	left := int(math.Floor(float64(min3(x0, x1, x2))))
+	right := int(math.Ceil(float64(max3(x0, x1, x2))))
+	top := int(math.Floor(float64(min3(y0, y1, y2))))
+	bottom := int(math.Ceil(float64(max3(y0, y1, y2))))
+	left, top = maximum(left, host.clipLeft), maximum(top, host.clipTop)
+	right, bottom = minimum(right, host.clipRight-1), minimum(bottom, host.clipBottom-1)
+
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			centerX, centerY := float32(x)+0.5, float32(y)+0.5
+			weight0 := edge(x1, y1, x2, y2, centerX, centerY) / area
+			weight1 := edge(x2, y2, x0, y0, centerX, centerY) / area
+			weight2 := edge(x0, y0, x1, y1, centerX, centerY) / area
+			if weight0 >= 0 && weight1 >= 0 && weight2 >= 0 {
+				shade(x, y, weight0, weight1, weight2)
+			}
+		}
+	}
+}
+
+func min3(a, b, c float32) float32 {
+	return float32(math.Min(float64(a), math.Min(float64(b), float64(c))))
+}
+
+func max3(a, b, c float32) float32 {
+	return float32(math.Max(float64(a), math.Max(float64(b), float64(c))))
+}
+
+func rawTri(x0, y0, x1, y1, x2, y2 float32, color int8) {
+	mapped := mapColor(color)
+	rasterizeTriangle(x0, y0, x1, y1, x2, y2, func(x, y int, weight0, weight1, weight2 float32) {
+		plot(x, y, mapped)
+	})
+}
+
+func rawTrib(x0, y0, x1, y1, x2, y2 float32, color int8) {
+	rawLine(x0, y0, x1, y1, color)
+	rawLine(x1, y1, x2, y2, color)
+	rawLine(x2, y2, x0, y0, color)
+}
+
+func rawTtri(x0, y0, x1, y1, x2, y2, u0, v0, u1, v1, u2, v2 float32, useTiles int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, z0, z1, z2 float32, depth bool) {
+	transparent := transparentColors(transparentColorBuffer, transparentColorCount)
+	texture := SPRITES
+	if useTiles != 0 {
+		texture = TILES
+	}
+
+	rasterizeTriangle(x0, y0, x1, y1, x2, y2, func(x, y int, weight0, weight1, weight2 float32) {
+		var u, v float32
+		if depth && z0 != 0 && z1 != 0 && z2 != 0 {
+			inverse := weight0/z0 + weight1/z1 + weight2/z2
+			u = (weight0*u0/z0 + weight1*u1/z1 + weight2*u2/z2) / inverse
+			v = (weight0*v0/z0 + weight1*v1/z1 + weight2*v2/z2) / inverse
+		} else {
+			u = weight0*u0 + weight1*u1 + weight2*u2
+			v = weight0*v0 + weight1*v1 + weight2*v2
+		}
+
+		textureX, textureY := wrap(int(math.Floor(float64(u))), 128), wrap(int(math.Floor(float64(v))), 128)
+		color := texture.Tile(textureY/8*16+textureX/8).Pixel(textureX%8, textureY%8)
+		if !transparent[color] {
+			plot(x, y, PALETTE_MAP.Color(color))
+		}
+	})
+}
+
+// plotTile draws a tile to the screen.
+func plotTile(tile *Tile, x, y, scale int, transparent *[16]bool) {
+	for tileY := 0; tileY < 8; tileY++ {
+		for tileX := 0; tileX < 8; tileX++ {
+			if color := tile.Pixel(tileX, tileY); !transparent[color] {
+				plotBlock(x+tileX*scale, y+tileY*scale, scale, PALETTE_MAP.Color(color))
+			}
+		}
+	}
+}
+
+func rawSpr(id, x, y int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, scale, flip, rotate, width, height int32) {
+	transparent := transparentColors(transparentColorBuffer, transparentColorCount)
+	sourceWidth, sourceHeight := int(width)*8, int(height)*8
+	rotation := wrap(int(rotate), 4)
+	targetWidth, targetHeight := sourceWidth, sourceHeight
+	if rotation%2 == 1 {
+		targetWidth, targetHeight = sourceHeight, sourceWidth
+	}
+
+	for targetY := 0; targetY < targetHeight; targetY++ {
+		for targetX := 0; targetX < targetWidth; targetX++ {
+			var sourceX, sourceY int
+			switch rotation {
+			case 0:
+				sourceX, sourceY = targetX, targetY
+			case 1:
+				sourceX, sourceY = targetY, sourceHeight-1-targetX
+			case 2:
+				sourceX, sourceY = sourceWidth-1-targetX, sourceHeight-1-targetY
+			case 3:
+				sourceX, sourceY = sourceWidth-1-targetY, targetX
+			}
+			if flip&1 != 0 {
+				sourceX = sourceWidth - 1 - sourceX
+			}
+			if flip&2 != 0 {
+				sourceY = sourceHeight - 1 - sourceY
+			}
+
+			tile := sheetTile(int(id) + sourceX/8 + sourceY/8*16)
+			if color := tile.Pixel(sourceX%8, sourceY%8); !transparent[color] {
+				plotBlock(int(x)+targetX*int(scale), int(y)+targetY*int(scale), int(scale), PALETTE_MAP.Color(color))
+			}
+		}
+	}
+}
+
+func rawMap(x, y, width, height, screenX, screenY int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, unused int32) {
+	transparent := transparentColors(transparentColorBuffer, transparentColorCount)
+	for mapY := int32(0); mapY < height; mapY++ {
+		for mapX := int32(0); mapX < width; mapX++ {
+			tile := MAP.Tile(wrap(int(x+mapX), MAP_WIDTH), wrap(int(y+mapY), MAP_HEIGHT))
+			plotTile(TILES.Tile(tile), int(screenX+mapX*8), int(screenY+mapY*8), 1, &transparent)
+		}
+	}
+}
+
+// plotText draws text to the screen one character at a time, and returns its width.
+// Each character is drawn by plotCharacter, which returns the width of the character if it is not fixed.
+func plotText(text string, x, y, width, height int, fixed bool, scale int, plotCharacter func(character byte, x, y int) int) int {
+	position, textWidth := x, 0
+	for index := 0; index < len(text); index++ {
+		if text[index] == '\n' {
+			textWidth = maximum(textWidth, position-x)
+			position = x
+			y += height * scale
+			continue
+		}
+		size := plotCharacter(text[index], position, y)
+		if !fixed && size > 0 {
+			position += (size + 1) * scale
+		} else {
+			position += width * scale
+		}
+	}
+	return maximum(textWidth, position-x)
+}
+
+// columns returns the leftmost and rightmost columns for which set returns true, or -1 if there are none.
+func columns(width, height int, set func(x, y int) bool) (left, right int) {
+	left, right = -1, -1
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			if set(x, y) {
+				if left < 0 {
+					left = x
+				}
+				right = x
+				break
+			}
+		}
+	}
+	return
+}
+
+func rawPrint(textBuffer unsafe.Pointer, x, y int32, color, fixed, scale, useAlternateFontPage int8) int32 {
+	width, height, offset := 6, 6, byte(0)
+	if useAlternateFontPage != 0 {
+		width, offset = 4, ALTERNATE_FONT_OFFSET
+	}
+	mapped := mapColor(color)
+
+	return int32(plotText(fromTextData(textBuffer), int(x), int(y), width, height, fixed != 0, int(scale), func(character byte, x, y int) int {
+		if offset > 0 {
+			character = character%ALTERNATE_FONT_OFFSET + offset
+		}
+		set := func(glyphX, glyphY int) bool {
+			return FONT.Pixel(character, glyphX, glyphY)
+		}
+		left, right := 0, width-1
+		if fixed == 0 {
+			if left, right = columns(width, height, set); left < 0 {
+				return 0
+			}
+		}
+		for glyphY := 0; glyphY < height; glyphY++ {
+			for glyphX := left; glyphX <= right; glyphX++ {
+				if set(glyphX, glyphY) {
+					plotBlock(x+(glyphX-left)*int(scale), y+glyphY*int(scale), int(scale), mapped)
+				}
+			}
+		}
+		return right - left + 1
+	}))
+}
+
+func rawFont(textBuffer unsafe.Pointer, x, y int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, characterWidth, characterHeight int8, fixed bool, scale int8, useAlternateFontPage bool) int32 {
+	transparent := transparentColors(transparentColorBuffer, transparentColorCount)
+	width, height := minimum(int(characterWidth), 8), minimum(int(characterHeight), 8)
+	page := 0
+	if useAlternateFontPage {
+		page = 256
+	}
+
+	return int32(plotText(fromTextData(textBuffer), int(x), int(y), int(characterWidth), int(characterHeight), fixed, int(scale), func(character byte, x, y int) int {
+		tile := sheetTile(page + int(character))
+		set := func(tileX, tileY int) bool {
+			return !transparent[tile.Pixel(tileX, tileY)]
+		}
+		left, right := 0, width-1
+		if !fixed {
+			if left, right = columns(width, height, set); left < 0 {
+				return 0
+			}
+		}
+		for tileY := 0; tileY < height; tileY++ {
+			for tileX := left; tileX <= right; tileX++ {
+				if set(tileX, tileY) {
+					plotBlock(x+(tileX-left)*int(scale), y+tileY*int(scale), int(scale), PALETTE_MAP.Color(tile.Pixel(tileX, tileY)))
+				}
+			}
+		}
+		return right - left + 1
+	}))
+}
@@ -0,0 +1,309 @@
+//go:build !tinygo
+
+package tic80
+
+import (
+	"strings"
+	"testing"
+)
+
+// screenRegion returns the colors of the screen in a rectangle, as rows of hexadecimal digits.
+func screenRegion(x, y, width, height int) []string {
+	rows := make([]string, height)
+	for row := range rows {
+		var builder strings.Builder
+		for column := 0; column < width; column++ {
+			builder.WriteByte("0123456789abcdef"[SCREEN.Pixel(x+column, y+row)])
+		}
+		rows[row] = builder.String()
+	}
+	return rows
+}
+
+// glyphRows returns the rows of a glyph of the host fonts as they should be drawn in a color, padded to a width.
+func glyphRows(glyph [6]string, width int, color byte) []string {
+	rows := make([]string, len(glyph))
+	for row, pixels := range glyph {
+		pixels += strings.Repeat(".", width)
+		rows[row] = strings.NewReplacer(".", "0", "#", string(color)).Replace(pixels[:width])
+	}
+	return rows
+}
+
+// compareRegion reports every row of the screen region that differs from the expected rows.
+func compareRegion(t *testing.T, name string, x, y int, expected []string) {
+	t.Helper()
+	actual := screenRegion(x, y, len(expected[0]), len(expected))
+	for row := range expected {
+		if actual[row] != expected[row] {
+			t.Errorf("%s: row %d is %s, expected %s\nscreen:\n%s", name, row, actual[row], expected[row], strings.Join(actual, "\n"))
+			return
+		}
+	}
+}
+
+func TestPrintFonts(t *testing.T) {
+	fonts := []struct {
+		name    string
+		glyphs  map[byte][6]string
+		width   int
+		options *PrintOptions
+	}{
+		{"large", hostFont, 6, NewPrintOptions().SetColor(12).ToggleFixed()},
+		{"small", hostAlternateFont, 4, NewPrintOptions().SetColor(12).ToggleFixed().TogglePage()},
+	}
+	for _, font := range fonts {
+		for character, glyph := range font.glyphs {
+			HostReset()
+			width := Print(string(character), 0, 0, font.options)
+			if width != font.width {
+				t.Errorf("%s %q: fixed width is %d, expected %d", font.name, character, width, font.width)
+			}
+			compareRegion(t, font.name+" "+string(character), 0, 0, glyphRows(glyph, font.width+1, 'c'))
+		}
+	}
+}
+
+func TestPrintProportional(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		options  *PrintOptions
+		width    int
+		expected []string
+	}{
+		{"large", "ia", NewPrintOptions().SetColor(3), 7, []string{
+			"30000000",
+			"00033300",
+			"30300300",
+			"30300300",
+			"30033300",
+			"00000000",
+		}},
+		{"small", "ia", NewPrintOptions().SetColor(3).TogglePage(), 6, []string{
+			"30000000",
+			"00033000",
+			"30303000",
+			"30303000",
+			"30033000",
+			"00000000",
+		}},
+		{"small scaled", "l", NewPrintOptions().SetColor(5).TogglePage().SetScale(2), 6, []string{
+			"550000",
+			"550000",
+			"550000",
+			"550000",
+			"550000",
+			"550000",
+			"550000",
+			"550000",
+			"005500",
+			"005500",
+		}},
+	}
+	for _, test := range tests {
+		HostReset()
+		if width := Print(test.text, 0, 0, test.options); width != test.width {
+			t.Errorf("%s: width is %d, expected %d", test.name, width, test.width)
+		}
+		compareRegion(t, test.name, 0, 0, test.expected)
+	}
+}
+
+func TestPrimitives(t *testing.T) {
+	tests := []struct {
+		name     string
+		draw     func()
+		expected []string
+	}{
+		{"rect", func() { Rect(1, 1, 3, 2, 5) }, []string{
+			"00000",
+			"05550",
+			"05550",
+			"00000",
+		}},
+		{"rect empty", func() { Rect(1, 1, 0, 2, 5) }, []string{
+			"000",
+			"000",
+			"000",
+		}},
+		{"rectb", func() { Rectb(0, 0, 4, 3, 6) }, []string{
+			"66660",
+			"60060",
+			"66660",
+			"00000",
+		}},
+		{"circ", func() { Circ(3, 3, 2, 7) }, []string{
+			"0000000",
+			"0077700",
+			"0777770",
+			"0777770",
+			"0777770",
+			"0077700",
+			"0000000",
+		}},
+		{"circb", func() { Circb(3, 3, 2, 8) }, []string{
+			"0000000",
+			"0088800",
+			"0800080",
+			"0800080",
+			"0800080",
+			"0088800",
+			"0000000",
+		}},
+		{"elli", func() { Elli(4, 2, 3, 1, 7) }, []string{
+			"000000000",
+			"007777700",
+			"077777770",
+			"007777700",
+			"000000000",
+		}},
+		{"line", func() { Line(0, 0, 5, 2, 9) }, []string{
+			"9900000",
+			"0099000",
+			"0000990",
+			"0000000",
+		}},
+		{"line rising", func() { Line(0, 3, 3, 0, 9) }, []string{
+			"00090",
+			"00900",
+			"09000",
+			"90000",
+			"00000",
+		}},
+		{"tri", func() { Tri(0, 0, 5, 0, 0, 5, 10) }, []string{
+			"aaaaa00",
+			"aaaa000",
+			"aaa0000",
+			"aa00000",
+			"a000000",
+			"0000000",
+		}},
+		{"trib", func() { Trib(0, 0, 5, 0, 0, 5, 10) }, []string{
+			"aaaaaa0",
+			"a000a00",
+			"a00a000",
+			"a0a0000",
+			"aa00000",
+			"a000000",
+			"0000000",
+		}},
+		{"pix", func() { Pix(2, 1, 4) }, []string{
+			"0000",
+			"0040",
+			"0000",
+		}},
+		{"clip", func() { Clip(1, 1, 2, 2); Rect(0, 0, 4, 4, 11) }, []string{
+			"0000",
+			"0bb0",
+			"0bb0",
+			"0000",
+		}},
+		{"palette map", func() { PALETTE_MAP.SetColor(5, 2); Rect(0, 0, 2, 1, 5) }, []string{
+			"220",
+		}},
+	}
+	for _, test := range tests {
+		HostReset()
+		test.draw()
+		compareRegion(t, test.name, 0, 0, test.expected)
+	}
+}
+
+func TestSpr(t *testing.T) {
+	// Sprite 0 has three pixels in its top left corner, and sprite 1 has one, so that every flip and rotation differs.
+	setup := func() {
+		SPRITES.Tile(0).SetPixel(0, 0, 1)
+		SPRITES.Tile(0).SetPixel(1, 0, 2)
+		SPRITES.Tile(0).SetPixel(0, 1, 3)
+		SPRITES.Tile(1).SetPixel(0, 0, 4)
+		Cls(15)
+	}
+	tests := []struct {
+		name          string
+		options       *SpriteOptions
+		width, height int
+		pixels        map[[2]int]byte
+	}{
+		{"none", NewSpriteOptions(), 8, 8, map[[2]int]byte{{0, 0}: '1', {1, 0}: '2', {0, 1}: '3'}},
+		{"flip horizontally", NewSpriteOptions().FlipHorizontally(), 8, 8, map[[2]int]byte{{7, 0}: '1', {6, 0}: '2', {7, 1}: '3'}},
+		{"flip vertically", NewSpriteOptions().FlipVertically(), 8, 8, map[[2]int]byte{{0, 7}: '1', {1, 7}: '2', {0, 6}: '3'}},
+		{"flip both", NewSpriteOptions().FlipHorizontally().FlipVertically(), 8, 8, map[[2]int]byte{{7, 7}: '1', {6, 7}: '2', {7, 6}: '3'}},
+		{"rotate clockwise", NewSpriteOptions().Rotate90CW(), 8, 8, map[[2]int]byte{{7, 0}: '1', {7, 1}: '2', {6, 0}: '3'}},
+		{"rotate 180", NewSpriteOptions().Rotate180(), 8, 8, map[[2]int]byte{{7, 7}: '1', {6, 7}: '2', {7, 6}: '3'}},
+		{"rotate counterclockwise", NewSpriteOptions().Rotate90CCW(), 8, 8, map[[2]int]byte{{0, 7}: '1', {0, 6}: '2', {1, 7}: '3'}},
+		{"flip then rotate", NewSpriteOptions().FlipHorizontally().Rotate90CW(), 8, 8, map[[2]int]byte{{7, 7}: '1', {7, 6}: '2', {6, 7}: '3'}},
+		{"scale", NewSpriteOptions().SetScale(2), 16, 16, map[[2]int]byte{
+			{0, 0}: '1', {1, 0}: '1', {0, 1}: '1', {1, 1}: '1',
+			{2, 0}: '2', {3, 0}: '2', {2, 1}: '2', {3, 1}: '2',
+			{0, 2}: '3', {1, 2}: '3', {0, 3}: '3', {1, 3}: '3',
+		}},
+		{"size", NewSpriteOptions().SetSize(2, 1), 16, 8, map[[2]int]byte{{0, 0}: '1', {1, 0}: '2', {0, 1}: '3', {8, 0}: '4'}},
+		{"size rotated", NewSpriteOptions().SetSize(2, 1).Rotate90CW(), 8, 16, map[[2]int]byte{{7, 0}: '1', {7, 1}: '2', {6, 0}: '3', {7, 8}: '4'}},
+		{"transparent", NewSpriteOptions().AddTransparentColor(0), 8, 8, map[[2]int]byte{{0, 0}: '1', {1, 0}: '2', {0, 1}: '3'}},
+	}
+	for _, test := range tests {
+		HostReset()
+		setup()
+		Spr(256, 0, 0, test.options)
+
+		background := byte('0')
+		if test.name == "transparent" {
+			background = 'f'
+		}
+		expected := make([]string, test.height+1)
+		for y := range expected {
+			row := []byte(strings.Repeat("f", test.width+1))
+			for x := 0; x < test.width && y < test.height; x++ {
+				row[x] = background
+				if color, found := test.pixels[[2]int{x, y}]; found {
+					row[x] = color
+				}
+			}
+			expected[y] = string(row)
+		}
+		compareRegion(t, test.name, 0, 0, expected)
+	}
+}
+
+func TestMap(t *testing.T) {
+	setup := func() {
+		TILES.Tile(1).SetPixel(0, 0, 4)
+		TILES.Tile(2).SetPixel(7, 7, 6)
+		MAP.SetTile(1, 0, 1)
+		MAP.SetTile(2, 1, 2)
+		Cls(15)
+	}
+	tests := []struct {
+		name    string
+		options *MapOptions
+		drawn   [4]int
+		pixels  map[[2]int]byte
+	}{
+		{"whole", NewMapOptions().SetSize(3, 2), [4]int{0, 0, 24, 16}, map[[2]int]byte{{8, 0}: '4', {23, 15}: '6'}},
+		{"offset", NewMapOptions().SetOffset(1, 0).SetSize(2, 2), [4]int{0, 0, 16, 16}, map[[2]int]byte{{0, 0}: '4', {15, 15}: '6'}},
+		{"position", NewMapOptions().SetSize(3, 2).SetPosition(1, 2), [4]int{1, 2, 24, 16}, map[[2]int]byte{{9, 2}: '4', {24, 17}: '6'}},
+		{"transparent", NewMapOptions().SetSize(3, 2).AddTransparentColor(0), [4]int{0, 0, 0, 0}, map[[2]int]byte{{8, 0}: '4', {23, 15}: '6'}},
+	}
+	for _, test := range tests {
+		HostReset()
+		setup()
+		Map(test.options)
+
+		expected := make([]string, 20)
+		for y := range expected {
+			row := []byte(strings.Repeat("f", 28))
+			for x := range row {
+				left, top, width, height := test.drawn[0], test.drawn[1], test.drawn[2], test.drawn[3]
+				if x >= left && x < left+width && y >= top && y < top+height {
+					row[x] = '0'
+				}
+				if color, found := test.pixels[[2]int{x, y}]; found {
+					row[x] = color
+				}
+			}
+			expected[y] = string(row)
+		}
+		compareRegion(t, test.name, 0, 0, expected)
+	}
+}
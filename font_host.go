@@ -0,0 +1,200 @@
+//go:build !tinygo
+
+package tic80
+
+// hostFont is the system font loaded into [tic80.FONT] on the host, as rows of pixels drawn from the top left.
+var hostFont = map[byte][6]string{
+	'!':  {"#....", "#....", "#....", ".....", "#....", "....."},
+	'"':  {"#.#..", "#.#..", ".....", ".....", ".....", "....."},
+	'#':  {".#.#.", "#####", ".#.#.", "#####", ".#.#.", "....."},
+	'$':  {".###.", "#.#..", ".###.", "..#.#", ".###.", "....."},
+	'%':  {"#...#", "...#.", "..#..", ".#...", "#...#", "....."},
+	'&':  {".##..", "#..#.", ".##..", "#..#.", ".##.#", "....."},
+	'\'': {"#....", "#....", ".....", ".....", ".....", "....."},
+	'(':  {".#...", "#....", "#....", "#....", ".#...", "....."},
+	')':  {"#....", ".#...", ".#...", ".#...", "#....", "....."},
+	'*':  {".....", "#.#..", ".#...", "#.#..", ".....", "....."},
+	'+':  {".....", ".#...", "###..", ".#...", ".....", "....."},
+	',':  {".....", ".....", ".....", ".#...", ".#...", "#...."},
+	'-':  {".....", ".....", "###..", ".....", ".....", "....."},
+	'.':  {".....", ".....", ".....", ".....", "#....", "....."},
+	'/':  {"...#.", "..#..", ".#...", "#....", ".....", "....."},
+	'0':  {".###.", "#..##", "#.#.#", "##..#", ".###.", "....."},
+	'1':  {".#...", "##...", ".#...", ".#...", "###..", "....."},
+	'2':  {"###..", "...#.", ".##..", "#....", "####.", "....."},
+	'3':  {"###..", "...#.", ".##..", "...#.", "###..", "....."},
+	'4':  {"#..#.", "#..#.", "####.", "...#.", "...#.", "....."},
+	'5':  {"####.", "#....", "###..", "...#.", "###..", "....."},
+	'6':  {".##..", "#....", "###..", "#..#.", ".##..", "....."},
+	'7':  {"####.", "...#.", "..#..", ".#...", ".#...", "....."},
+	'8':  {".##..", "#..#.", ".##..", "#..#.", ".##..", "....."},
+	'9':  {".##..", "#..#.", ".###.", "...#.", ".##..", "....."},
+	':':  {".....", "#....", ".....", "#....", ".....", "....."},
+	';':  {".....", ".#...", ".....", ".#...", "#....", "....."},
+	'<':  {"..#..", ".#...", "#....", ".#...", "..#..", "....."},
+	'=':  {".....", "###..", ".....", "###..", ".....", "....."},
+	'>':  {"#....", ".#...", "..#..", ".#...", "#....", "....."},
+	'?':  {"###..", "...#.", ".##..", ".....", ".#...", "....."},
+	'@':  {".###.", "#.###", "#.#.#", "#.###", ".##..", "....."},
+	'A':  {".##..", "#..#.", "####.", "#..#.", "#..#.", "....."},
+	'B':  {"###..", "#..#.", "###..", "#..#.", "###..", "....."},
+	'C':  {".###.", "#....", "#....", "#....", ".###.", "....."},
+	'D':  {"###..", "#..#.", "#..#.", "#..#.", "###..", "....."},
+	'E':  {"####.", "#....", "###..", "#....", "####.", "....."},
+	'F':  {"####.", "#....", "###..", "#....", "#....", "....."},
+	'G':  {".###.", "#....", "#.##.", "#..#.", ".###.", "....."},
+	'H':  {"#..#.", "#..#.", "####.", "#..#.", "#..#.", "....."},
+	'I':  {"###..", ".#...", ".#...", ".#...", "###..", "....."},
+	'J':  {"...#.", "...#.", "...#.", "#..#.", ".##..", "....."},
+	'K':  {"#..#.", "#.#..", "##...", "#.#..", "#..#.", "....."},
+	'L':  {"#....", "#....", "#....", "#....", "####.", "....."},
+	'M':  {"#...#", "##.##", "#.#.#", "#...#", "#...#", "....."},
+	'N':  {"#..#.", "##.#.", "#.##.", "#..#.", "#..#.", "....."},
+	'O':  {".##..", "#..#.", "#..#.", "#..#.", ".##..", "....."},
+	'P':  {"###..", "#..#.", "###..", "#....", "#....", "....."},
+	'Q':  {".##..", "#..#.", "#..#.", "#.#..", ".#.#.", "....."},
+	'R':  {"###..", "#..#.", "###..", "#.#..", "#..#.", "....."},
+	'S':  {".###.", "#....", ".##..", "...#.", "###..", "....."},
+	'T':  {"###..", ".#...", ".#...", ".#...", ".#...", "....."},
+	'U':  {"#..#.", "#..#.", "#..#.", "#..#.", ".##..", "....."},
+	'V':  {"#..#.", "#..#.", "#..#.", ".##..", ".##..", "....."},
+	'W':  {"#...#", "#...#", "#.#.#", "##.##", "#...#", "....."},
+	'X':  {"#..#.", "#..#.", ".##..", "#..#.", "#..#.", "....."},
+	'Y':  {"#.#..", "#.#..", ".#...", ".#...", ".#...", "....."},
+	'Z':  {"####.", "..#..", ".#...", "#....", "####.", "....."},
+	'[':  {"##...", "#....", "#....", "#....", "##...", "....."},
+	'\\': {"#....", ".#...", "..#..", "...#.", ".....", "....."},
+	']':  {"##...", ".#...", ".#...", ".#...", "##...", "....."},
+	'^':  {".#...", "#.#..", ".....", ".....", ".....", "....."},
+	'_':  {".....", ".....", ".....", ".....", "####.", "....."},
+	'`':  {"#....", ".#...", ".....", ".....", ".....", "....."},
+	'a':  {".....", ".###.", "#..#.", "#..#.", ".###.", "....."},
+	'b':  {"#....", "###..", "#..#.", "#..#.", "###..", "....."},
+	'c':  {".....", ".##..", "#....", "#....", ".##..", "....."},
+	'd':  {"...#.", ".###.", "#..#.", "#..#.", ".###.", "....."},
+	'e':  {".....", ".##..", "####.", "#....", ".##..", "....."},
+	'f':  {".#...", "#.#..", "###..", "#....", "#....", "....."},
+	'g':  {".....", ".###.", "#..#.", ".###.", "...#.", ".##.."},
+	'h':  {"#....", "###..", "#..#.", "#..#.", "#..#.", "....."},
+	'i':  {"#....", ".....", "#....", "#....", "#....", "....."},
+	'j':  {".#...", ".....", ".#...", ".#...", ".#...", "#...."},
+	'k':  {"#....", "#..#.", "###..", "#..#.", "#..#.", "....."},
+	'l':  {"#....", "#....", "#....", "#....", ".#...", "....."},
+	'm':  {".....", "##.#.", "#.#.#", "#.#.#", "#.#.#", "....."},
+	'n':  {".....", "###..", "#..#.", "#..#.", "#..#.", "....."},
+	'o':  {".....", ".##..", "#..#.", "#..#.", ".##..", "....."},
+	'p':  {".....", "###..", "#..#.", "#..#.", "###..", "#...."},
+	'q':  {".....", ".###.", "#..#.", "#..#.", ".###.", "...#."},
+	'r':  {".....", "#.#..", "##...", "#....", "#....", "....."},
+	's':  {".....", ".##..", "##...", "..#..", "##...", "....."},
+	't':  {"#....", "###..", "#....", "#....", ".##..", "....."},
+	'u':  {".....", "#..#.", "#..#.", "#..#.", ".###.", "....."},
+	'v':  {".....", "#.#..", "#.#..", "#.#..", ".#...", "....."},
+	'w':  {".....", "#...#", "#.#.#", "#.#.#", ".#.#.", "....."},
+	'x':  {".....", "#.#..", ".#...", ".#...", "#.#..", "....."},
+	'y':  {".....", "#..#.", "#..#.", ".###.", "...#.", ".##.."},
+	'z':  {".....", "###..", "..#..", ".#...", "###..", "....."},
+	'{':  {"..#..", ".#...", "##...", ".#...", "..#..", "....."},
+	'|':  {"#....", "#....", "#....", "#....", "#....", "....."},
+	'}':  {"#....", ".#...", ".##..", ".#...", "#....", "....."},
+	'~':  {".....", ".#.#.", "#.#..", ".....", ".....", "....."},
+}
+
+// hostAlternateFont is the small system font loaded into [tic80.FONT] after the large one on the host,
+// as rows of pixels drawn from the top left.
+var hostAlternateFont = map[byte][6]string{
+	'!':  {"#...", "#...", "#...", "....", "#...", "...."},
+	'"':  {"#.#.", "#.#.", "....", "....", "....", "...."},
+	'#':  {"#.#.", "###.", "#.#.", "###.", "#.#.", "...."},
+	'$':  {".##.", "##..", ".#..", ".##.", "##..", "...."},
+	'%':  {"#.#.", "..#.", ".#..", "#...", "#.#.", "...."},
+	'&':  {".#..", "#.#.", ".##.", "#.#.", ".##.", "...."},
+	'\'': {"#...", "#...", "....", "....", "....", "...."},
+	'(':  {".#..", "#...", "#...", "#...", ".#..", "...."},
+	')':  {"#...", ".#..", ".#..", ".#..", "#...", "...."},
+	'*':  {"....", "#.#.", ".#..", "#.#.", "....", "...."},
+	'+':  {"....", ".#..", "###.", ".#..", "....", "...."},
+	',':  {"....", "....", "....", ".#..", "#...", "...."},
+	'-':  {"....", "....", "###.", "....", "....", "...."},
+	'.':  {"....", "....", "....", "....", "#...", "...."},
+	'/':  {"..#.", "..#.", ".#..", "#...", "#...", "...."},
+	'0':  {"###.", "#.#.", "#.#.", "#.#.", "###.", "...."},
+	'1':  {".#..", "##..", ".#..", ".#..", "###.", "...."},
+	'2':  {"###.", "..#.", "###.", "#...", "###.", "...."},
+	'3':  {"###.", "..#.", ".##.", "..#.", "###.", "...."},
+	'4':  {"#.#.", "#.#.", "###.", "..#.", "..#.", "...."},
+	'5':  {"###.", "#...", "###.", "..#.", "###.", "...."},
+	'6':  {"###.", "#...", "###.", "#.#.", "###.", "...."},
+	'7':  {"###.", "..#.", ".#..", ".#..", ".#..", "...."},
+	'8':  {"###.", "#.#.", "###.", "#.#.", "###.", "...."},
+	'9':  {"###.", "#.#.", "###.", "..#.", "###.", "...."},
+	':':  {"....", "#...", "....", "#...", "....", "...."},
+	';':  {"....", ".#..", "....", ".#..", "#...", "...."},
+	'<':  {"..#.", ".#..", "#...", ".#..", "..#.", "...."},
+	'=':  {"....", "###.", "....", "###.", "....", "...."},
+	'>':  {"#...", ".#..", "..#.", ".#..", "#...", "...."},
+	'?':  {"###.", "..#.", ".##.", "....", ".#..", "...."},
+	'@':  {".#..", "#.#.", "###.", "#...", ".##.", "...."},
+	'A':  {".#..", "#.#.", "###.", "#.#.", "#.#.", "...."},
+	'B':  {"##..", "#.#.", "##..", "#.#.", "##..", "...."},
+	'C':  {".##.", "#...", "#...", "#...", ".##.", "...."},
+	'D':  {"##..", "#.#.", "#.#.", "#.#.", "##..", "...."},
+	'E':  {"###.", "#...", "##..", "#...", "###.", "...."},
+	'F':  {"###.", "#...", "##..", "#...", "#...", "...."},
+	'G':  {".##.", "#...", "#.#.", "#.#.", ".##.", "...."},
+	'H':  {"#.#.", "#.#.", "###.", "#.#.", "#.#.", "...."},
+	'I':  {"###.", ".#..", ".#..", ".#..", "###.", "...."},
+	'J':  {"..#.", "..#.", "..#.", "#.#.", ".#..", "...."},
+	'K':  {"#.#.", "#.#.", "##..", "#.#.", "#.#.", "...."},
+	'L':  {"#...", "#...", "#...", "#...", "###.", "...."},
+	'M':  {"#.#.", "###.", "###.", "#.#.", "#.#.", "...."},
+	'N':  {"##..", "#.#.", "#.#.", "#.#.", "#.#.", "...."},
+	'O':  {".#..", "#.#.", "#.#.", "#.#.", ".#..", "...."},
+	'P':  {"##..", "#.#.", "##..", "#...", "#...", "...."},
+	'Q':  {".#..", "#.#.", "#.#.", "##..", ".##.", "...."},
+	'R':  {"##..", "#.#.", "##..", "#.#.", "#.#.", "...."},
+	'S':  {".##.", "#...", ".#..", "..#.", "##..", "...."},
+	'T':  {"###.", ".#..", ".#..", ".#..", ".#..", "...."},
+	'U':  {"#.#.", "#.#.", "#.#.", "#.#.", "###.", "...."},
+	'V':  {"#.#.", "#.#.", "#.#.", "#.#.", ".#..", "...."},
+	'W':  {"#.#.", "#.#.", "###.", "###.", "#.#.", "...."},
+	'X':  {"#.#.", "#.#.", ".#..", "#.#.", "#.#.", "...."},
+	'Y':  {"#.#.", "#.#.", ".#..", ".#..", ".#..", "...."},
+	'Z':  {"###.", "..#.", ".#..", "#...", "###.", "...."},
+	'[':  {"##..", "#...", "#...", "#...", "##..", "...."},
+	'\\': {"#...", "#...", ".#..", "..#.", "..#.", "...."},
+	']':  {"##..", ".#..", ".#..", ".#..", "##..", "...."},
+	'^':  {".#..", "#.#.", "....", "....", "....", "...."},
+	'_':  {"....", "....", "....", "....", "###.", "...."},
+	'`':  {"#...", ".#..", "....", "....", "....", "...."},
+	'a':  {"....", ".##.", "#.#.", "#.#.", ".##.", "...."},
+	'b':  {"#...", "##..", "#.#.", "#.#.", "##..", "...."},
+	'c':  {"....", ".##.", "#...", "#...", ".##.", "...."},
+	'd':  {"..#.", ".##.", "#.#.", "#.#.", ".##.", "...."},
+	'e':  {"....", ".#..", "###.", "#...", ".##.", "...."},
+	'f':  {".##.", "#...", "##..", "#...", "#...", "...."},
+	'g':  {"....", ".##.", "#.#.", ".##.", "..#.", "##.."},
+	'h':  {"#...", "##..", "#.#.", "#.#.", "#.#.", "...."},
+	'i':  {"#...", "....", "#...", "#...", "#...", "...."},
+	'j':  {".#..", "....", ".#..", ".#..", ".#..", "#..."},
+	'k':  {"#...", "#.#.", "##..", "#.#.", "#.#.", "...."},
+	'l':  {"#...", "#...", "#...", "#...", ".#..", "...."},
+	'm':  {"....", "##..", "###.", "#.#.", "#.#.", "...."},
+	'n':  {"....", "##..", "#.#.", "#.#.", "#.#.", "...."},
+	'o':  {"....", ".#..", "#.#.", "#.#.", ".#..", "...."},
+	'p':  {"....", "##..", "#.#.", "#.#.", "##..", "#..."},
+	'q':  {"....", ".##.", "#.#.", "#.#.", ".##.", "..#."},
+	'r':  {"....", "#.#.", "##..", "#...", "#...", "...."},
+	's':  {"....", ".##.", "##..", "..#.", "##..", "...."},
+	't':  {"#...", "##..", "#...", "#...", ".#..", "...."},
+	'u':  {"....", "#.#.", "#.#.", "#.#.", ".##.", "...."},
+	'v':  {"....", "#.#.", "#.#.", "#.#.", ".#..", "...."},
+	'w':  {"....", "#.#.", "#.#.", "###.", "###.", "...."},
+	'x':  {"....", "#.#.", ".#..", ".#..", "#.#.", "...."},
+	'y':  {"....", "#.#.", "#.#.", ".##.", "..#.", ".#.."},
+	'z':  {"....", "###.", ".#..", "#...", "###.", "...."},
+	'{':  {".##.", ".#..", "##..", ".#..", ".##.", "...."},
+	'|':  {"#...", "#...", "#...", "#...", "#...", "...."},
+	'}':  {"##..", ".#..", ".##.", ".#..", "##..", "...."},
+	'~':  {"....", ".##.", "##..", "....", "....", "...."},
+}
@@ -0,0 +1,111 @@
+package tic80
+
+// Game represents the callbacks TIC-80 makes into a cartridge.
+// See the [API] for more details.
+//
+// [API]: https://github.com/nesbox/TIC-80/wiki/Code#functions
+type Game interface {
+	// Boot is called once, after the runtime has started but before the first call to Tic.
+	Boot()
+
+	// Tic is called 60 times per second to update and draw the game.
+	Tic()
+
+	// Bdr is called before each scanline is drawn, including the rows of the border.
+	// See [tic80.RasterEffects] for how scanlines are numbered.
+	//
+	// TIC-80 calls into the cartridge for each of the 144 scanlines of every frame, so Bdr should be kept short.
+	// A game that leaves Bdr to [tic80.BaseGame] is only called for the first scanline; the rest return as soon as any
+	// [tic80.RasterEffects] are applied.
+	Bdr(scanline int)
+
+	// Ovr is called after Tic to draw the overlay layer.
+	Ovr()
+
+	// Menu is called when the game menu item specified by index is selected.
+	Menu(index int)
+}
+
+// BaseGame provides empty implementations of the optional callbacks of [tic80.Game].
+// Embed it in a game to leave out the callbacks that it does not need.
+type BaseGame struct{}
+
+// Boot does nothing.
+func (game BaseGame) Boot() {}
+
+// Bdr does nothing, and marks that the game does not need to be called for each scanline.
+func (game BaseGame) Bdr(scanline int) {
+	bdrEmpty = true
+}
+
+// Ovr does nothing.
+func (game BaseGame) Ovr() {}
+
+// Menu does nothing.
+func (game BaseGame) Menu(index int) {}
+
+var (
+	currentGame Game
+	started     bool
+	bdrEmpty    bool
+)
+
+// Run registers the game to receive the callbacks from TIC-80.
+// It should be called from main or an init function.
+//
+// When a game is run this way, [tic80.Start] is called automatically before [tic80.Game.Boot], and should not be called again.
+func Run(game Game) {
+	currentGame = game
+	bdrEmpty = false
+}
+
+// boot starts the runtime and boots the game, if it has not been done already.
+func boot() {
+	if !started {
+		started = true
+		Start()
+		if currentGame == nil {
+			panic("tic80: no game registered with Run")
+		}
+		currentGame.Boot()
+	}
+}
+
+//go:export BOOT
+func exportBoot() {
+	boot()
+}
+
+//go:export TIC
+func exportTic() {
+	// Versions of TIC-80 that predate BOOT call TIC first.
+	boot()
+	if currentInputPlayer != nil {
+		currentInputPlayer.advance()
+	}
+	if currentMusicEvents != nil {
+		currentMusicEvents.check()
+	}
+	currentGame.Tic()
+}
+
+//go:export BDR
+func exportBdr(scanline int32) {
+	if currentRasterEffects != nil {
+		currentRasterEffects.apply(int(scanline))
+	}
+	if bdrEmpty {
+		return
+	}
+	currentGame.Bdr(int(scanline))
+}
+
+//go:export OVR
+func exportOvr() {
+	currentGame.Ovr()
+}
+
+//go:export MENU
+func exportMenu(index int32) {
+	currentGame.Menu(int(index))
+}
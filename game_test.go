@@ -0,0 +1,55 @@
+//go:build !tinygo
+
+package tic80
+
+import "testing"
+
+// quietGame leaves Bdr to BaseGame, and counts the frames it is run for.
+type quietGame struct {
+	BaseGame
+	tics int
+}
+
+func (game *quietGame) Tic() {
+	game.tics++
+}
+
+// scanlineGame counts the scanlines it is called for.
+type scanlineGame struct {
+	quietGame
+	scanlines int
+}
+
+func (game *scanlineGame) Bdr(scanline int) {
+	game.scanlines++
+}
+
+func TestBdr(t *testing.T) {
+	quiet := new(quietGame)
+	Run(quiet)
+	HostReset()
+	applied := 0
+	SetRasterEffects(NewRasterEffects().SetCallback(func(scanline int) {
+		applied++
+	}))
+	defer SetRasterEffects(nil)
+	for frame := 0; frame < 2; frame++ {
+		HostTick()
+	}
+	if quiet.tics != 2 || !bdrEmpty {
+		t.Errorf("tics = %d and bdrEmpty = %t after two frames, want 2 and true", quiet.tics, bdrEmpty)
+	}
+	if applied != 2*SCANLINE_COUNT {
+		t.Errorf("raster effects were applied to %d scanlines, want %d", applied, 2*SCANLINE_COUNT)
+	}
+
+	counting := new(scanlineGame)
+	Run(counting)
+	HostReset()
+	for frame := 0; frame < 2; frame++ {
+		HostTick()
+	}
+	if bdrEmpty || counting.scanlines != 2*SCANLINE_COUNT {
+		t.Errorf("Bdr was called for %d scanlines, want %d", counting.scanlines, 2*SCANLINE_COUNT)
+	}
+}
@@ -0,0 +1,92 @@
+package tic80
+
+// InputState is a snapshot of the buttons of all four gamepads, read from [tic80.GAMEPADS] in one go each frame,
+// that remembers the previous frame to tell when buttons are pressed and released.
+//
+// [tic80.InputState.Update] must be called once every frame, at the start of [tic80.Game.Tic].
+type InputState struct {
+	current  uint32
+	previous uint32
+	holds    [32]int
+	active   uint32
+}
+
+// NewInputState constructs a [tic80.InputState] object with no buttons pressed and no players active.
+func NewInputState() *InputState {
+	return new(InputState)
+}
+
+// Update reads the buttons of all four gamepads for this frame.
+func (state *InputState) Update() {
+	state.previous = state.current
+	state.current = GAMEPADS.State()
+	for id := range state.holds {
+		if state.current&(1<<id) != 0 {
+			state.holds[id]++
+		} else {
+			state.holds[id] = 0
+		}
+	}
+	state.active |= state.current
+}
+
+// Pressed returns true if the specified button is pressed; false otherwise.
+func (state *InputState) Pressed(id ButtonCode) bool {
+	return state.current&(1<<(id%32)) != 0
+}
+
+// JustPressed returns true if the specified button was pressed this frame, after not being pressed the frame before; false otherwise.
+func (state *InputState) JustPressed(id ButtonCode) bool {
+	mask := uint32(1) << (id % 32)
+	return state.current&mask != 0 && state.previous&mask == 0
+}
+
+// JustReleased returns true if the specified button stopped being pressed this frame; false otherwise.
+func (state *InputState) JustReleased(id ButtonCode) bool {
+	mask := uint32(1) << (id % 32)
+	return state.current&mask == 0 && state.previous&mask != 0
+}
+
+// HeldFrames returns the number of frames the specified button has been pressed for, including this one,
+// or 0 if it is not pressed.
+func (state *InputState) HeldFrames(id ButtonCode) int {
+	return state.holds[id%32]
+}
+
+// Buttons returns the buttons of a player, from [tic80.GAMEPAD_1] to [tic80.GAMEPAD_4],
+// where each of [tic80.BUTTON_UP] to [tic80.BUTTON_Y] is a bit.
+func (state *InputState) Buttons(player ButtonCode) byte {
+	return byte(state.current >> (player % 32 / 8 * 8))
+}
+
+// JustPressedButtons returns the buttons of a player that were pressed this frame, as bits like [tic80.InputState.Buttons].
+func (state *InputState) JustPressedButtons(player ButtonCode) byte {
+	return byte((state.current &^ state.previous) >> (player % 32 / 8 * 8))
+}
+
+// JustReleasedButtons returns the buttons of a player that were released this frame, as bits like [tic80.InputState.Buttons].
+func (state *InputState) JustReleasedButtons(player ButtonCode) byte {
+	return byte((state.previous &^ state.current) >> (player % 32 / 8 * 8))
+}
+
+// Active returns true if a player, from [tic80.GAMEPAD_1] to [tic80.GAMEPAD_4],
+// has pressed any button since the input state was constructed or reset; false otherwise.
+func (state *InputState) Active(player ButtonCode) bool {
+	return byte(state.active>>(player%32/8*8)) != 0
+}
+
+// ActivePlayers returns the players, from [tic80.GAMEPAD_1] to [tic80.GAMEPAD_4], that are active.
+func (state *InputState) ActivePlayers() []ButtonCode {
+	var players []ButtonCode
+	for player := GAMEPAD_1; player <= GAMEPAD_4; player += GAMEPAD_2 {
+		if state.Active(player) {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// Reset forgets which players are active, such as when returning to the title screen.
+func (state *InputState) Reset() {
+	state.active = 0
+}
@@ -0,0 +1,263 @@
+package tic80
+
+// KeyboardPage is an enumeration of the pages of keys of a [tic80.VirtualKeyboard].
+type KeyboardPage int
+
+// Keyboard Pages
+const (
+	KEYBOARD_PAGE_LOWER KeyboardPage = iota
+	KEYBOARD_PAGE_UPPER
+	KEYBOARD_PAGE_SYMBOLS
+)
+
+// Virtual Keyboard Dimensions
+const (
+	VIRTUAL_KEY_WIDTH  = 12
+	VIRTUAL_KEY_HEIGHT = 11
+)
+
+// virtualKeyAction is what choosing a key of a [tic80.VirtualKeyboard] does, other than typing its rune.
+type virtualKeyAction int
+
+const (
+	virtualKeyType virtualKeyAction = iota
+	virtualKeyShift
+	virtualKeySymbols
+	virtualKeyErase
+	virtualKeyDone
+)
+
+// virtualKey is one key of a [tic80.VirtualKeyboard], laid out in pixels relative to the keyboard.
+type virtualKey struct {
+	x      int
+	width  int
+	label  string
+	typed  rune
+	action virtualKeyAction
+}
+
+// virtualKeyboardPages are the default rows of each page.
+var virtualKeyboardPages = [3][]string{
+	{"1234567890", "qwertyuiop", "asdfghjkl-", "zxcvbnm,.'"},
+	{"1234567890", "QWERTYUIOP", "ASDFGHJKL_", "ZXCVBNM!?\""},
+	{"!@#$%^&*()", "-_=+[]{}\\|", ";:'\",.<>/?", "`~"},
+}
+
+// VirtualKeyboard is an on-screen keyboard that types into a [tic80.LineField] with a gamepad,
+// for players that have no keyboard.
+// The d-pad moves between the keys, [tic80.BUTTON_A] chooses a key and [tic80.BUTTON_B] erases a rune.
+// The last row has keys for shift, which capitalizes the next rune, the symbols page, space, erase and done.
+type VirtualKeyboard struct {
+	field    *LineField
+	pages    [3][]string
+	page     KeyboardPage
+	row      int
+	column   int
+	player   ButtonCode
+	x        int
+	y        int
+	colors   [4]int
+	finished bool
+}
+
+// NewVirtualKeyboard constructs a [tic80.VirtualKeyboard] object that types into the specified field,
+// read from the gamepad of [tic80.GAMEPAD_1] and drawn at the top left of the screen.
+func NewVirtualKeyboard(field *LineField) *VirtualKeyboard {
+	return &VirtualKeyboard{
+		field:  field,
+		pages:  virtualKeyboardPages,
+		player: GAMEPAD_1,
+		colors: [4]int{0, 15, 13, 4},
+	}
+}
+
+// SetPosition sets the position of the top left corner of the keyboard.
+func (keyboard *VirtualKeyboard) SetPosition(x, y int) *VirtualKeyboard {
+	keyboard.x = x
+	keyboard.y = y
+	return keyboard
+}
+
+// SetPlayer sets the player whose gamepad is read, from [tic80.GAMEPAD_1] to [tic80.GAMEPAD_4].
+func (keyboard *VirtualKeyboard) SetPlayer(player ButtonCode) *VirtualKeyboard {
+	keyboard.player = player % 32 / 8 * 8
+	return keyboard
+}
+
+// SetColors sets the colors of the background, the keys, the selected key and the labels.
+func (keyboard *VirtualKeyboard) SetColors(background, key, selected, label int) *VirtualKeyboard {
+	keyboard.colors = [4]int{background % 16, key % 16, selected % 16, label % 16}
+	return keyboard
+}
+
+// SetPage sets the rows of keys of a page, where each rune of a row is a key.
+func (keyboard *VirtualKeyboard) SetPage(page KeyboardPage, rows ...string) *VirtualKeyboard {
+	keyboard.pages[page%3] = append([]string(nil), rows...)
+	return keyboard
+}
+
+// columns returns the number of keys in the widest row of any page.
+func (keyboard *VirtualKeyboard) columns() int {
+	columns := 0
+	for _, rows := range keyboard.pages {
+		for _, row := range rows {
+			columns = maximum(columns, len([]rune(row)))
+		}
+	}
+	return columns
+}
+
+// keys returns the rows of keys of the current page, followed by the row of special keys.
+func (keyboard *VirtualKeyboard) keys() [][]virtualKey {
+	var keys [][]virtualKey
+	for _, row := range keyboard.pages[keyboard.page] {
+		var line []virtualKey
+		for column, typed := range []rune(row) {
+			line = append(line, virtualKey{x: column * VIRTUAL_KEY_WIDTH, width: VIRTUAL_KEY_WIDTH, label: string(typed), typed: typed})
+		}
+		if len(line) > 0 {
+			keys = append(keys, line)
+		}
+	}
+
+	symbols := "#+="
+	if keyboard.page == KEYBOARD_PAGE_SYMBOLS {
+		symbols = "abc"
+	}
+	special := []virtualKey{
+		{label: "SHF", action: virtualKeyShift},
+		{label: symbols, action: virtualKeySymbols},
+		{label: "SPC", typed: ' '},
+		{label: "DEL", action: virtualKeyErase},
+		{label: "OK", action: virtualKeyDone},
+	}
+	width := maximum(keyboard.columns(), len(special)) * VIRTUAL_KEY_WIDTH
+	for index := range special {
+		special[index].x = index * width / len(special)
+		special[index].width = (index+1)*width/len(special) - special[index].x
+	}
+	return append(keys, special)
+}
+
+// Size returns the width and height of the keyboard in pixels.
+func (keyboard *VirtualKeyboard) Size() (width, height int) {
+	width = maximum(keyboard.columns(), 5) * VIRTUAL_KEY_WIDTH
+	rows := 1
+	for _, row := range keyboard.pages[keyboard.page] {
+		if row != "" {
+			rows++
+		}
+	}
+	return width + 1, rows*VIRTUAL_KEY_HEIGHT + 1
+}
+
+// Finished returns true if the done key was chosen this frame; false otherwise.
+func (keyboard *VirtualKeyboard) Finished() bool {
+	return keyboard.finished
+}
+
+// move moves the selection by a number of rows and columns, wrapping around the edges.
+// Moving between rows selects the key closest to the center of the selected one.
+func (keyboard *VirtualKeyboard) move(keys [][]virtualKey, rows, columns int) {
+	keyboard.column += columns
+	if columns != 0 {
+		length := len(keys[keyboard.row])
+		keyboard.column = (keyboard.column%length + length) % length
+	}
+	if rows == 0 {
+		return
+	}
+
+	selected := keys[keyboard.row][keyboard.column]
+	center := selected.x + selected.width/2
+	keyboard.row = ((keyboard.row+rows)%len(keys) + len(keys)) % len(keys)
+	closest := -1
+	for column, key := range keys[keyboard.row] {
+		distance := absolute(key.x + key.width/2 - center)
+		if closest < 0 || distance < closest {
+			keyboard.column, closest = column, distance
+		}
+	}
+}
+
+// choose does what the selected key does.
+func (keyboard *VirtualKeyboard) choose(key virtualKey) {
+	switch key.action {
+	case virtualKeyType:
+		keyboard.field.Insert(key.typed)
+		if keyboard.page == KEYBOARD_PAGE_UPPER {
+			keyboard.page = KEYBOARD_PAGE_LOWER
+		}
+	case virtualKeyShift:
+		if keyboard.page == KEYBOARD_PAGE_LOWER {
+			keyboard.page = KEYBOARD_PAGE_UPPER
+		} else {
+			keyboard.page = KEYBOARD_PAGE_LOWER
+		}
+	case virtualKeySymbols:
+		if keyboard.page == KEYBOARD_PAGE_SYMBOLS {
+			keyboard.page = KEYBOARD_PAGE_LOWER
+		} else {
+			keyboard.page = KEYBOARD_PAGE_SYMBOLS
+		}
+	case virtualKeyErase:
+		keyboard.field.Erase()
+	case virtualKeyDone:
+		keyboard.finished = true
+	}
+}
+
+// Tic reads the gamepad, types into the field and draws the keyboard, and must be called once every frame while it is shown.
+// It returns true if the done key was chosen this frame; false otherwise.
+func (keyboard *VirtualKeyboard) Tic() bool {
+	keyboard.finished = false
+	keyboard.field.blink++
+
+	keys := keyboard.keys()
+	keyboard.row = minimum(keyboard.row, len(keys)-1)
+	keyboard.column = minimum(keyboard.column, len(keys[keyboard.row])-1)
+	pressed := func(button ButtonCode) bool {
+		return Btnp(keyboard.player+button, TEXT_REPEAT_HOLD, TEXT_REPEAT_PERIOD)
+	}
+	switch {
+	case pressed(BUTTON_UP):
+		keyboard.move(keys, -1, 0)
+	case pressed(BUTTON_DOWN):
+		keyboard.move(keys, 1, 0)
+	case pressed(BUTTON_LEFT):
+		keyboard.move(keys, 0, -1)
+	case pressed(BUTTON_RIGHT):
+		keyboard.move(keys, 0, 1)
+	case pressed(BUTTON_A):
+		keyboard.choose(keys[keyboard.row][keyboard.column])
+	case pressed(BUTTON_B):
+		keyboard.field.Erase()
+	}
+
+	// Choosing a key may have changed the page, and with it the keys.
+	keys = keyboard.keys()
+	keyboard.row = minimum(keyboard.row, len(keys)-1)
+	keyboard.column = minimum(keyboard.column, len(keys[keyboard.row])-1)
+	keyboard.draw(keys)
+	return keyboard.finished
+}
+
+// draw draws the keys, with the selected key highlighted.
+func (keyboard *VirtualKeyboard) draw(keys [][]virtualKey) {
+	width, height := keyboard.Size()
+	Rect(keyboard.x, keyboard.y, width, height, keyboard.colors[0])
+	options := NewPrintOptions().SetColor(keyboard.colors[3]).ToggleFixed()
+	for row, line := range keys {
+		y := keyboard.y + 1 + row*VIRTUAL_KEY_HEIGHT
+		for column, key := range line {
+			x := keyboard.x + 1 + key.x
+			color := keyboard.colors[1]
+			if row == keyboard.row && column == keyboard.column {
+				color = keyboard.colors[2]
+			}
+			Rect(x, y, key.width-1, VIRTUAL_KEY_HEIGHT-1, color)
+			labelWidth := len(key.label)*6 - 1
+			Print(key.label, x+(key.width-1-labelWidth)/2, y+(VIRTUAL_KEY_HEIGHT-1-5)/2, options)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package tic80
+
+// Layer is one of the two video banks, which are drawn one over the other.
+type Layer int
+
+// Layers
+const (
+	LAYER_BACKGROUND Layer = iota
+	LAYER_OVERLAY
+)
+
+// Draw switches to the video bank of the layer, calls draw, then switches back to the previous video bank.
+func (layer Layer) Draw(draw func()) {
+	previousBank := Vbank(int(layer))
+	defer Vbank(previousBank)
+	draw()
+}
+
+// Palette returns a copy of the palette of the layer.
+func (layer Layer) Palette() Palette {
+	return LoadPalette(int(layer))
+}
+
+// SetPalette sets the palette of the layer.
+func (layer Layer) SetPalette(palette Palette) {
+	StorePalette(int(layer), palette)
+}
+
+// BorderColor returns the border color of the layer.
+// In [tic80.LAYER_OVERLAY], this is the color that is drawn as transparent.
+func (layer Layer) BorderColor() (color int) {
+	layer.Draw(func() {
+		color = BORDER.Color()
+	})
+	return
+}
+
+// SetBorderColor sets the border color of the layer.
+// In [tic80.LAYER_OVERLAY], this is the color that is drawn as transparent.
+func (layer Layer) SetBorderColor(color int) {
+	layer.Draw(func() {
+		BORDER.SetColor(color)
+	})
+}
+
+// PaletteMap returns a copy of the palette map of the layer.
+func (layer Layer) PaletteMap() (paletteMap PaletteMapMemory) {
+	layer.Draw(func() {
+		paletteMap = *PALETTE_MAP
+	})
+	return
+}
+
+// SetPaletteMap sets the palette map of the layer.
+func (layer Layer) SetPaletteMap(paletteMap PaletteMapMemory) {
+	layer.Draw(func() {
+		*PALETTE_MAP = paletteMap
+	})
+}
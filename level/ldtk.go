@@ -0,0 +1,215 @@
+package level
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type ldtkTile struct {
+	Px []int `json:"px"`
+	T  int   `json:"t"`
+}
+
+type ldtkLayer struct {
+	Type           string     `json:"__type"`
+	Identifier     string     `json:"__identifier"`
+	Width          int        `json:"__cWid"`
+	Height         int        `json:"__cHei"`
+	GridSize       int        `json:"__gridSize"`
+	TilesetUID     *int       `json:"__tilesetDefUid"`
+	GridTiles      []ldtkTile `json:"gridTiles"`
+	AutoLayerTiles []ldtkTile `json:"autoLayerTiles"`
+	Entities       []struct {
+		Identifier string `json:"__identifier"`
+		Px         []int  `json:"px"`
+		Width      int    `json:"width"`
+		Height     int    `json:"height"`
+		Fields     []struct {
+			Identifier string      `json:"__identifier"`
+			Type       string      `json:"__type"`
+			Value      interface{} `json:"__value"`
+		} `json:"fieldInstances"`
+	} `json:"entityInstances"`
+}
+
+type ldtkProject struct {
+	Defs struct {
+		Tilesets []struct {
+			UID      int `json:"uid"`
+			Columns  int `json:"__cWid"`
+			Rows     int `json:"__cHei"`
+			EnumTags []struct {
+				Value   string `json:"enumValueId"`
+				TileIDs []int  `json:"tileIds"`
+			} `json:"enumTags"`
+			CustomData []struct {
+				TileID int    `json:"tileId"`
+				Data   string `json:"data"`
+			} `json:"customData"`
+		} `json:"tilesets"`
+	} `json:"defs"`
+	Levels []struct {
+		Identifier string       `json:"identifier"`
+		Layers     []*ldtkLayer `json:"layerInstances"`
+	} `json:"levels"`
+}
+
+// ldtkField returns the value of an LDtk field of the given type, decoded from JSON.
+func ldtkField(fieldType string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case float64:
+		if fieldType == "Int" {
+			return int(value)
+		}
+		return value
+	case bool:
+		return value
+	case string:
+		return value
+	case nil:
+		switch fieldType {
+		case "Int":
+			return 0
+		case "Float":
+			return 0.0
+		case "Bool":
+			return false
+		}
+		return ""
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
+
+// tilesets returns the tilesets of the project by their uids, placed in the tiles in the order they are defined,
+// and the flags of their tiles.
+// Tiles set flags with enum tags named "flag0" to "flag7", or with custom data of the form "flags=<number>".
+func (project *ldtkProject) tilesets() (map[int]*editorTileset, map[int]byte, error) {
+	byUID := make(map[int]*editorTileset)
+	sets := make([]*editorTileset, 0, len(project.Defs.Tilesets))
+	for _, tileset := range project.Defs.Tilesets {
+		set := &editorTileset{columns: tileset.Columns, count: tileset.Columns * tileset.Rows, flags: make(map[int]byte)}
+		byUID[tileset.UID] = set
+		sets = append(sets, set)
+		for _, tag := range tileset.EnumTags {
+			tagFlags, ok := flagsFromProperty(tag.Value, true)
+			if !ok {
+				continue
+			}
+			for _, tile := range tag.TileIDs {
+				set.flags[tile] |= tagFlags
+			}
+		}
+		for _, data := range tileset.CustomData {
+			for _, line := range strings.Split(data.Data, "\n") {
+				name, value, found := strings.Cut(strings.TrimSpace(line), "=")
+				if !found {
+					continue
+				}
+				var number int
+				if _, err := fmt.Sscan(value, &number); err != nil {
+					continue
+				}
+				if dataFlags, ok := flagsFromProperty(strings.TrimSpace(name), number); ok {
+					set.flags[data.TileID] |= dataFlags
+				}
+			}
+		}
+	}
+	if err := arrangeTilesets(sets); err != nil {
+		return nil, nil, err
+	}
+	return byUID, tilesetFlags(sets), nil
+}
+
+// loadLDtk loads a level from an LDtk project.
+// Tile layers are either Tiles layers or the tiles of auto layers, and objects are the entities of Entities layers.
+func loadLDtk(path string, options *LoadOptions) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var project ldtkProject
+	if err := json.Unmarshal(data, &project); err != nil {
+		return nil, fmt.Errorf("level: %s: %w", path, err)
+	}
+
+	for _, ldtkLevel := range project.Levels {
+		if options.level != "" && ldtkLevel.Identifier != options.level {
+			continue
+		}
+		if ldtkLevel.Layers == nil {
+			return nil, fmt.Errorf("level: level %s is saved in a separate file, which is not supported", ldtkLevel.Identifier)
+		}
+
+		tilesets, flags, err := project.tilesets()
+		if err != nil {
+			return nil, err
+		}
+		level := &Level{Flags: flags}
+		for _, layer := range ldtkLevel.Layers {
+			switch layer.Type {
+			case "Tiles", "AutoLayer", "IntGrid":
+				tiles := layer.GridTiles
+				if layer.Type != "Tiles" {
+					tiles = layer.AutoLayerTiles
+				}
+				if level.Tiles != nil || len(tiles) == 0 || (options.layer != "" && layer.Identifier != options.layer) {
+					continue
+				}
+				var tileset *editorTileset
+				if layer.TilesetUID != nil {
+					tileset = tilesets[*layer.TilesetUID]
+				}
+				if tileset == nil {
+					return nil, fmt.Errorf("level: layer %s has no tileset", layer.Identifier)
+				}
+				level.Width, level.Height = layer.Width, layer.Height
+				level.Tiles = make([]int, layer.Width*layer.Height)
+				for _, tile := range tiles {
+					if len(tile.Px) != 2 || layer.GridSize == 0 {
+						continue
+					}
+					x, y := tile.Px[0]/layer.GridSize, tile.Px[1]/layer.GridSize
+					if x >= 0 && x < layer.Width && y >= 0 && y < layer.Height {
+						level.Tiles[y*layer.Width+x] = tileset.tile(tile.T)
+					}
+				}
+
+			case "Entities":
+				for _, entity := range layer.Entities {
+					if len(entity.Px) != 2 {
+						continue
+					}
+					object := Object{
+						Type:   entity.Identifier,
+						X:      entity.Px[0],
+						Y:      entity.Px[1],
+						Width:  entity.Width,
+						Height: entity.Height,
+					}
+					for _, field := range entity.Fields {
+						value := ldtkField(field.Type, field.Value)
+						if strings.EqualFold(field.Identifier, "name") {
+							object.Name = fmt.Sprint(value)
+							continue
+						}
+						object.Properties = append(object.Properties, Property{field.Identifier, value})
+					}
+					level.Objects = append(level.Objects, object)
+				}
+			}
+		}
+		if level.Tiles == nil {
+			return nil, errors.New("level: the level has no matching tile layer")
+		}
+		return level, nil
+	}
+	if options.level == "" {
+		return nil, errors.New("level: the project has no levels")
+	}
+	return nil, fmt.Errorf("level: the project has no level named %s", options.level)
+}
@@ -0,0 +1,344 @@
+// Package level imports levels made in the Tiled and LDtk editors into the map of a cartridge.
+//
+// The tiles of one tile layer are written to a [tic80.MapMemory], the flags set on the tiles of the tileset are written
+// to a [tic80.SpriteFlagMemory], and the objects of the object layers are written out as Go source.
+package level
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sorucoder/tic80"
+)
+
+// Property is a custom property of an object, whose value is an int, float64, bool or string.
+type Property struct {
+	Name  string
+	Value interface{}
+}
+
+// Object is an object placed in a level, such as a spawn point or a trigger, measured in pixels.
+type Object struct {
+	Name       string
+	Type       string
+	X          int
+	Y          int
+	Width      int
+	Height     int
+	Properties []Property
+}
+
+// Level is a level imported from an editor.
+type Level struct {
+	// Width and Height are the size of the level in tiles.
+	Width  int
+	Height int
+
+	// Tiles holds the id of the tile at each cell, row by row.
+	Tiles []int
+
+	// Flags holds the flags of each tile id that has any set.
+	Flags map[int]byte
+
+	Objects []Object
+}
+
+// sheetColumns is the number of tiles in each row of the tiles of a cartridge.
+const sheetColumns = 16
+
+// editorTileset is a tileset of an editor, with the flags of its tiles.
+// Its tiles are placed in the tiles of the cartridge as they are laid out in its image,
+// starting at the first row below the tilesets before it.
+type editorTileset struct {
+	columns int
+	count   int
+	offset  int
+	flags   map[int]byte
+}
+
+// tile returns the id in the tiles of the cartridge of a tile of the tileset.
+func (set *editorTileset) tile(id int) int {
+	return set.offset + id/set.columns*sheetColumns + id%set.columns
+}
+
+// arrangeTilesets places the tilesets one below another, in order.
+// A tileset without columns, such as a collection of images, is placed as if it were as wide as the tiles.
+// It returns an error if a tileset is wider than the tiles.
+func arrangeTilesets(tilesets []*editorTileset) error {
+	offset := 0
+	for _, set := range tilesets {
+		if set.columns <= 0 {
+			set.columns = sheetColumns
+		}
+		if set.columns > sheetColumns {
+			return fmt.Errorf("level: a tileset is %d tiles wide, but the tiles of a cartridge are %d wide", set.columns, sheetColumns)
+		}
+		set.offset = offset
+		offset += (set.count + set.columns - 1) / set.columns * sheetColumns
+	}
+	return nil
+}
+
+// tilesetFlags gathers the flags of the tilesets, by where their tiles are placed.
+func tilesetFlags(tilesets []*editorTileset) map[int]byte {
+	flags := make(map[int]byte)
+	for _, set := range tilesets {
+		for id, tileFlags := range set.flags {
+			flags[set.tile(id)] |= tileFlags
+		}
+	}
+	return flags
+}
+
+// LoadOptions provides additional options to [level.Load].
+type LoadOptions struct {
+	level string
+	layer string
+}
+
+var defaultLoadOptions LoadOptions = LoadOptions{
+	level: "",
+	layer: "",
+}
+
+// NewLoadOptions constructs a [level.LoadOptions] object with the defaults.
+func NewLoadOptions() *LoadOptions {
+	options := new(LoadOptions)
+	*options = defaultLoadOptions
+	return options
+}
+
+// SetLevel sets the name of the level to load from an LDtk project, instead of the first level.
+func (options *LoadOptions) SetLevel(name string) *LoadOptions {
+	options.level = name
+	return options
+}
+
+// SetLayer sets the name of the tile layer to load, instead of the first tile layer.
+func (options *LoadOptions) SetLayer(name string) *LoadOptions {
+	options.layer = name
+	return options
+}
+
+// Load loads a level from a file, according to its extension: .tmx and .json (or .tmj) for Tiled maps, and .ldtk for LDtk projects.
+// External tilesets of Tiled maps are loaded relative to the map.
+func Load(path string, options *LoadOptions) (*Level, error) {
+	if options == nil {
+		options = &defaultLoadOptions
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tmx":
+		return loadTiledXML(path, options)
+	case ".json", ".tmj":
+		return loadTiledJSON(path, options)
+	case ".ldtk":
+		return loadLDtk(path, options)
+	}
+	return nil, fmt.Errorf("level: %s is not a Tiled map or LDtk project", path)
+}
+
+// flagsFromProperty returns the flags set by a property of a tile, and whether the property sets any.
+// A tile sets its flags with an int property named "flags", or with bool properties named "flag0" to "flag7".
+func flagsFromProperty(name string, value interface{}) (byte, bool) {
+	name = strings.ToLower(name)
+	if name == "flags" {
+		switch value := value.(type) {
+		case int:
+			return byte(value), true
+		case float64:
+			return byte(value), true
+		}
+		return 0, false
+	}
+	if strings.HasPrefix(name, "flag") {
+		flag, err := strconv.Atoi(name[len("flag"):])
+		if err == nil && flag >= 0 && flag < 8 {
+			if set, ok := value.(bool); ok && set {
+				return 1 << flag, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// Apply writes the tiles of the level to the map at the given map coordinates, and the flags of its tiles to the sprite flags.
+// Either may be nil to leave it unchanged.
+// It returns an error, and changes neither, if the level does not fit on the map or uses a tile outside of 0 to 255.
+func (level *Level) Apply(tileMap *tic80.MapMemory, flags *tic80.SpriteFlagMemory, x, y int) error {
+	if tileMap != nil {
+		if x < 0 || y < 0 || x+level.Width > tic80.MAP_WIDTH || y+level.Height > tic80.MAP_HEIGHT {
+			return errors.New("level: the level does not fit on the map")
+		}
+		for _, tile := range level.Tiles {
+			if tile < 0 || tile > 255 {
+				return fmt.Errorf("level: tile %d is outside of the tiles of the map", tile)
+			}
+		}
+	}
+	if flags != nil {
+		for tile := range level.Flags {
+			if tile < 0 || tile > 255 {
+				return fmt.Errorf("level: tile %d is outside of the tiles of the map", tile)
+			}
+		}
+	}
+
+	if tileMap != nil {
+		for cellY := 0; cellY < level.Height; cellY++ {
+			for cellX := 0; cellX < level.Width; cellX++ {
+				tileMap.SetTile(x+cellX, y+cellY, level.Tiles[cellY*level.Width+cellX])
+			}
+		}
+	}
+	if flags != nil {
+		for tile, tileFlags := range level.Flags {
+			for flag := 0; flag < 8; flag++ {
+				flags.SetFlag(tile, flag, tileFlags&(1<<flag) != 0)
+			}
+		}
+	}
+	return nil
+}
+
+// identifier transforms a name into a Go identifier, in either CamelCase or SCREAMING_CASE.
+func identifier(name string, screaming bool) string {
+	var builder strings.Builder
+	upper := true
+	for _, character := range name {
+		switch {
+		case !unicode.IsLetter(character) && !unicode.IsDigit(character):
+			if screaming && builder.Len() > 0 {
+				builder.WriteRune('_')
+			}
+			upper = true
+		case screaming:
+			builder.WriteRune(unicode.ToUpper(character))
+		case upper:
+			builder.WriteRune(unicode.ToUpper(character))
+			upper = false
+		default:
+			builder.WriteRune(character)
+		}
+	}
+	result := strings.TrimRight(builder.String(), "_")
+	if result == "" || !unicode.IsLetter([]rune(result)[0]) {
+		if screaming {
+			result = "OBJECT_" + result
+		} else {
+			result = "Object" + result
+		}
+	}
+	return result
+}
+
+// goType returns the Go type of a property value.
+func goType(value interface{}) string {
+	switch value.(type) {
+	case int:
+		return "int"
+	case float64:
+		return "float64"
+	case bool:
+		return "bool"
+	}
+	return "string"
+}
+
+// objectType is the Go struct generated for the objects of one type.
+type objectType struct {
+	name    string
+	fields  []string
+	types   map[string]string
+	objects []Object
+}
+
+// objectTypes groups the objects by type, and finds the fields of each type from the properties of its objects.
+// A property that has values of different types in the same type of object is generated as a string.
+func objectTypes(objects []Object) []*objectType {
+	var types []*objectType
+	byName := make(map[string]*objectType)
+	for _, object := range objects {
+		name := object.Type
+		if name == "" {
+			name = "object"
+		}
+		grouped := byName[name]
+		if grouped == nil {
+			grouped = &objectType{name: name, types: make(map[string]string)}
+			byName[name] = grouped
+			types = append(types, grouped)
+		}
+		grouped.objects = append(grouped.objects, object)
+		for _, property := range object.Properties {
+			field := identifier(property.Name, false)
+			switch previous, found := grouped.types[field]; {
+			case !found:
+				grouped.fields = append(grouped.fields, field)
+				grouped.types[field] = goType(property.Value)
+			case previous != goType(property.Value):
+				grouped.types[field] = "string"
+			}
+		}
+	}
+	for _, grouped := range types {
+		sort.Strings(grouped.fields)
+	}
+	return types
+}
+
+// goValue formats a property value as a Go literal of the given type.
+func goValue(value interface{}, valueType string) string {
+	if valueType == "string" {
+		if text, ok := value.(string); ok {
+			return strconv.Quote(text)
+		}
+		return strconv.Quote(fmt.Sprint(value))
+	}
+	return fmt.Sprint(value)
+}
+
+// WriteObjects writes a Go source file to the writer that declares a struct type for each type of object in the level,
+// with a field for each of their properties, and a slice of the objects of each type.
+// For example, objects of type "spawn_point" are declared as SPAWN_POINT_OBJECTS, a slice of SpawnPoint.
+func WriteObjects(writer io.Writer, packageName string, level *Level) error {
+	var source bytes.Buffer
+	fmt.Fprintf(&source, "// Code generated by tic80 level; DO NOT EDIT.\n\npackage %s\n", packageName)
+	for _, grouped := range objectTypes(level.Objects) {
+		typeName := identifier(grouped.name, false)
+		fmt.Fprintf(&source, "\n// %s is an object of type %q, measured in pixels.\ntype %s struct {\nName string\nX, Y, Width, Height int\n", typeName, grouped.name, typeName)
+		for _, field := range grouped.fields {
+			if field == "Name" || field == "X" || field == "Y" || field == "Width" || field == "Height" {
+				return fmt.Errorf("level: property %s of type %s conflicts with a field of every object", field, grouped.name)
+			}
+			fmt.Fprintf(&source, "%s %s\n", field, grouped.types[field])
+		}
+		source.WriteString("}\n")
+
+		fmt.Fprintf(&source, "\n// %s_OBJECTS are the objects of type %q.\nvar %s_OBJECTS = []%s{\n", identifier(grouped.name, true), grouped.name, identifier(grouped.name, true), typeName)
+		for _, object := range grouped.objects {
+			fmt.Fprintf(&source, "{Name: %q, X: %d, Y: %d, Width: %d, Height: %d", object.Name, object.X, object.Y, object.Width, object.Height)
+			for _, property := range object.Properties {
+				field := identifier(property.Name, false)
+				fmt.Fprintf(&source, ", %s: %s", field, goValue(property.Value, grouped.types[field]))
+			}
+			source.WriteString("},\n")
+		}
+		source.WriteString("}\n")
+	}
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return errors.New("level: could not format the generated source")
+	}
+	_, err = writer.Write(formatted)
+	return err
+}
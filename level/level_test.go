@@ -0,0 +1,184 @@
+package level
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sorucoder/tic80"
+)
+
+// The fixtures have a ground tileset of 8 by 2 tiles, placed at tile 0, and an items tileset of 4 by 2 tiles,
+// placed at the row below it, at tile 32.
+// In Tiled, ground tile 9 has flags 0 and 1, and items tile 3 has flag 2; in LDtk, ground tile 9 has flag 0.
+
+func TestLoadTiled(t *testing.T) {
+	expected := &Level{
+		Width:  3,
+		Height: 2,
+		Tiles:  []int{0, 17, 0, 32, 35, 0},
+		Flags:  map[int]byte{17: 3, 35: 4},
+		Objects: []Object{{
+			Name: "start", Type: "spawn", X: 8, Y: 16, Width: 8, Height: 8,
+			Properties: []Property{{"facing", "left"}, {"health", 3}},
+		}},
+	}
+	for _, name := range []string{"map.tmx", "map.tmj"} {
+		level, err := Load(filepath.Join("testdata", name), nil)
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(level, expected) {
+			t.Errorf("%s: loaded\n%+v\nexpected\n%+v", name, level, expected)
+		}
+	}
+}
+
+func TestLoadLDtk(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  *LoadOptions
+		expected *Level
+		error    string
+	}{
+		{"first layer", nil, &Level{
+			Width:  3,
+			Height: 2,
+			Tiles:  []int{35, 0, 0, 0, 32, 0},
+			Flags:  map[int]byte{17: 1, 35: 4},
+			Objects: []Object{{
+				Name: "start", Type: "Spawn", X: 8, Y: 16, Width: 8, Height: 8,
+				Properties: []Property{{"health", 3}, {"speed", 0.0}},
+			}},
+		}, ""},
+		{"named layer", NewLoadOptions().SetLayer("Ground"), &Level{
+			Width:  3,
+			Height: 2,
+			Tiles:  []int{17, 0, 0, 0, 0, 0},
+			Flags:  map[int]byte{17: 1, 35: 4},
+			Objects: []Object{{
+				Name: "start", Type: "Spawn", X: 8, Y: 16, Width: 8, Height: 8,
+				Properties: []Property{{"health", 3}, {"speed", 0.0}},
+			}},
+		}, ""},
+		{"separate level", NewLoadOptions().SetLevel("Level_1"), nil, "separate file"},
+		{"missing level", NewLoadOptions().SetLevel("Level_2"), nil, "no level named Level_2"},
+		{"missing layer", NewLoadOptions().SetLayer("Walls"), nil, "no matching tile layer"},
+	}
+	for _, test := range tests {
+		level, err := Load(filepath.Join("testdata", "project.ldtk"), test.options)
+		if test.error != "" {
+			if err == nil || !strings.Contains(err.Error(), test.error) {
+				t.Errorf("%s: error is %v, expected one about %q", test.name, err, test.error)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(level, test.expected) {
+			t.Errorf("%s: loaded\n%+v\nexpected\n%+v", test.name, level, test.expected)
+		}
+	}
+}
+
+func TestArrangeTilesets(t *testing.T) {
+	tilesets := []*editorTileset{
+		{columns: 16, count: 20},
+		{columns: 3, count: 7},
+		{count: 4},
+	}
+	if err := arrangeTilesets(tilesets); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		tileset  int
+		id       int
+		expected int
+	}{
+		{0, 19, 19},
+		{1, 0, 32},
+		{1, 4, 49},
+		{1, 6, 64},
+		{2, 3, 83},
+	}
+	for _, test := range tests {
+		if tile := tilesets[test.tileset].tile(test.id); tile != test.expected {
+			t.Errorf("tile %d of tileset %d is %d, expected %d", test.id, test.tileset, tile, test.expected)
+		}
+	}
+
+	if err := arrangeTilesets([]*editorTileset{{columns: 17, count: 17}}); err == nil {
+		t.Error("expected an error for a tileset wider than the tiles")
+	}
+}
+
+func TestApply(t *testing.T) {
+	level, err := Load(filepath.Join("testdata", "map.tmx"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var tileMap tic80.MapMemory
+	var flags tic80.SpriteFlagMemory
+	if err := level.Apply(&tileMap, &flags, 2, 1); err != nil {
+		t.Fatal(err)
+	}
+	if tileMap.Tile(3, 1) != 17 || tileMap.Tile(3, 2) != 35 || !flags.Flag(17, 1) || !flags.Flag(35, 2) || flags.Flag(35, 0) {
+		t.Error("the level was not applied to the map and flags")
+	}
+
+	if err := level.Apply(&tileMap, nil, tic80.MAP_WIDTH-2, 0); err == nil {
+		t.Error("expected an error for a level that does not fit on the map")
+	}
+	level.Tiles[0] = 256
+	before := tileMap
+	if err := level.Apply(&tileMap, nil, 0, 0); err == nil || tileMap != before {
+		t.Error("expected an error, and no change to the map, for a tile outside of the tiles")
+	}
+}
+
+func TestWriteObjects(t *testing.T) {
+	level, err := Load(filepath.Join("testdata", "map.tmx"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var source bytes.Buffer
+	if err := WriteObjects(&source, "game", level); err != nil {
+		t.Fatal(err)
+	}
+	expected := `// Code generated by tic80 level; DO NOT EDIT.
+
+package game
+
+// Spawn is an object of type "spawn", measured in pixels.
+type Spawn struct {
+	Name                string
+	X, Y, Width, Height int
+	Facing              string
+	Health              int
+}
+
+// SPAWN_OBJECTS are the objects of type "spawn".
+var SPAWN_OBJECTS = []Spawn{
+	{Name: "start", X: 8, Y: 16, Width: 8, Height: 8, Facing: "left", Health: 3},
+}
+`
+	if source.String() != expected {
+		t.Errorf("wrote\n%s\nexpected\n%s", source.String(), expected)
+	}
+}
+
+func TestLoadUnsupported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "level.txt")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path, nil); err == nil {
+		t.Error("expected an error for a file that is not a level")
+	}
+}
@@ -0,0 +1,9 @@
+<?xml version="1.0" encoding="UTF-8"?>
+<tileset version="1.10" tiledversion="1.10.2" name="items" tilewidth="8" tileheight="8" tilecount="8" columns="4">
+ <image source="items.png" width="32" height="16"/>
+ <tile id="3">
+  <properties>
+   <property name="flag2" type="bool" value="true"/>
+  </properties>
+ </tile>
+</tileset>
@@ -0,0 +1,509 @@
+package level
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tiledFlipFlags are the bits of a global tile id that flip or rotate the tile, which the map cannot represent.
+const tiledFlipFlags = 0xF0000000
+
+// tiledTileset is a tileset of a Tiled map, with the first global tile id of its tiles.
+type tiledTileset struct {
+	firstGID int
+	editorTileset
+}
+
+// tiledXMLValue returns the value of a Tiled property of the given type.
+func tiledXMLValue(propertyType, value string) interface{} {
+	switch propertyType {
+	case "int":
+		if number, err := strconv.Atoi(value); err == nil {
+			return number
+		}
+	case "float":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case "bool":
+		return value == "true"
+	}
+	return value
+}
+
+// tiledJSONValue returns the value of a Tiled property of the given type, decoded from JSON.
+func tiledJSONValue(propertyType string, value interface{}) interface{} {
+	switch value := value.(type) {
+	case float64:
+		if propertyType == "int" || propertyType == "object" {
+			return int(value)
+		}
+		return value
+	case bool:
+		return value
+	case string:
+		return value
+	}
+	return fmt.Sprint(value)
+}
+
+// arrangeTiledTilesets sorts the tilesets by their first global tile ids, places them in the tiles in that order,
+// and returns the flags of their tiles.
+func arrangeTiledTilesets(tilesets []*tiledTileset) (map[int]byte, error) {
+	sort.SliceStable(tilesets, func(i, j int) bool {
+		return tilesets[i].firstGID < tilesets[j].firstGID
+	})
+	sets := make([]*editorTileset, len(tilesets))
+	for index := range tilesets {
+		sets[index] = &tilesets[index].editorTileset
+	}
+	if err := arrangeTilesets(sets); err != nil {
+		return nil, err
+	}
+	return tilesetFlags(sets), nil
+}
+
+// tiledTiles transforms global tile ids into tile ids, by where the tileset that each belongs to is placed.
+func tiledTiles(gids []uint32, tilesets []*tiledTileset) []int {
+	tiles := make([]int, len(gids))
+	for index, gid := range gids {
+		gid &^= tiledFlipFlags
+		if gid == 0 {
+			continue
+		}
+		for _, tileset := range tilesets {
+			if tileset.firstGID <= int(gid) {
+				tiles[index] = tileset.tile(int(gid) - tileset.firstGID)
+			}
+		}
+	}
+	return tiles
+}
+
+// decodeTiledData decodes the global tile ids of a tile layer, stored as CSV or as base64 that may be compressed.
+func decodeTiledData(encoding, compression, data string) ([]uint32, error) {
+	switch encoding {
+	case "csv":
+		var gids []uint32
+		for _, field := range strings.Split(data, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			gid, err := strconv.ParseUint(field, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("level: %q is not a valid tile", field)
+			}
+			gids = append(gids, uint32(gid))
+		}
+		return gids, nil
+
+	case "base64":
+		raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(data))
+		if err != nil {
+			return nil, errors.New("level: tile layer contains invalid base64")
+		}
+		var reader io.Reader = bytes.NewReader(raw)
+		switch compression {
+		case "":
+		case "zlib":
+			if reader, err = zlib.NewReader(reader); err != nil {
+				return nil, err
+			}
+		case "gzip":
+			if reader, err = gzip.NewReader(reader); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("level: %s compression is not supported", compression)
+		}
+		if raw, err = io.ReadAll(reader); err != nil {
+			return nil, err
+		}
+		gids := make([]uint32, len(raw)/4)
+		for index := range gids {
+			gids[index] = binary.LittleEndian.Uint32(raw[index*4:])
+		}
+		return gids, nil
+	}
+	return nil, fmt.Errorf("level: %s encoding is not supported", encoding)
+}
+
+// tiledXMLProperty is a custom property in a Tiled XML file.
+type tiledXMLProperty struct {
+	Name  string `xml:"name,attr"`
+	Type  string `xml:"type,attr"`
+	Value string `xml:"value,attr"`
+	Text  string `xml:",chardata"`
+}
+
+// tiledXMLProperties transforms custom properties from a Tiled XML file.
+func tiledXMLProperties(xmlProperties []tiledXMLProperty) []Property {
+	properties := make([]Property, 0, len(xmlProperties))
+	for _, property := range xmlProperties {
+		value := property.Value
+		if value == "" {
+			value = property.Text
+		}
+		properties = append(properties, Property{property.Name, tiledXMLValue(property.Type, value)})
+	}
+	return properties
+}
+
+type tiledXMLTileset struct {
+	FirstGID  int    `xml:"firstgid,attr"`
+	Source    string `xml:"source,attr"`
+	TileCount int    `xml:"tilecount,attr"`
+	Columns   int    `xml:"columns,attr"`
+	Tiles     []struct {
+		ID         int                `xml:"id,attr"`
+		Properties []tiledXMLProperty `xml:"properties>property"`
+	} `xml:"tile"`
+}
+
+type tiledXMLLayers struct {
+	Layers []struct {
+		Name   string `xml:"name,attr"`
+		Width  int    `xml:"width,attr"`
+		Height int    `xml:"height,attr"`
+		Data   struct {
+			Encoding    string `xml:"encoding,attr"`
+			Compression string `xml:"compression,attr"`
+			Text        string `xml:",chardata"`
+			Tiles       []struct {
+				GID uint32 `xml:"gid,attr"`
+			} `xml:"tile"`
+			Chunks []struct{} `xml:"chunk"`
+		} `xml:"data"`
+	} `xml:"layer"`
+	ObjectGroups []struct {
+		Objects []struct {
+			Name       string             `xml:"name,attr"`
+			Type       string             `xml:"type,attr"`
+			Class      string             `xml:"class,attr"`
+			X          float64            `xml:"x,attr"`
+			Y          float64            `xml:"y,attr"`
+			Width      float64            `xml:"width,attr"`
+			Height     float64            `xml:"height,attr"`
+			Properties []tiledXMLProperty `xml:"properties>property"`
+		} `xml:"object"`
+	} `xml:"objectgroup"`
+	Groups []tiledXMLLayers `xml:"group"`
+}
+
+type tiledXMLMap struct {
+	Width    int               `xml:"width,attr"`
+	Height   int               `xml:"height,attr"`
+	Tilesets []tiledXMLTileset `xml:"tileset"`
+	tiledXMLLayers
+}
+
+// loadTiledXMLTileset loads a tileset from a Tiled XML map, or from the external file that it refers to.
+func loadTiledXMLTileset(directory string, tileset tiledXMLTileset) (*tiledTileset, error) {
+	if tileset.Source != "" {
+		firstGID := tileset.FirstGID
+		if strings.ToLower(filepath.Ext(tileset.Source)) != ".tsx" {
+			return loadTiledJSONTileset(directory, tiledJSONTileset{FirstGID: firstGID, Source: tileset.Source})
+		}
+		data, err := os.ReadFile(filepath.Join(directory, tileset.Source))
+		if err != nil {
+			return nil, err
+		}
+		source := tileset.Source
+		tileset = tiledXMLTileset{}
+		if err := xml.Unmarshal(data, &tileset); err != nil {
+			return nil, fmt.Errorf("level: %s: %w", source, err)
+		}
+		tileset.FirstGID = firstGID
+	}
+
+	loaded := &tiledTileset{tileset.FirstGID, editorTileset{columns: tileset.Columns, count: tileset.TileCount, flags: make(map[int]byte)}}
+	for _, tile := range tileset.Tiles {
+		if tile.ID >= loaded.count {
+			loaded.count = tile.ID + 1
+		}
+		for _, property := range tiledXMLProperties(tile.Properties) {
+			if flags, ok := flagsFromProperty(property.Name, property.Value); ok {
+				loaded.flags[tile.ID] |= flags
+			}
+		}
+	}
+	return loaded, nil
+}
+
+// collect adds the tile layer and objects of the layers, and of the groups within them, to the level.
+func (layers *tiledXMLLayers) collect(level *Level, tilesets []*tiledTileset, options *LoadOptions) error {
+	for _, layer := range layers.Layers {
+		if level.Tiles != nil || (options.layer != "" && layer.Name != options.layer) {
+			continue
+		}
+		if len(layer.Data.Chunks) > 0 {
+			return errors.New("level: infinite maps are not supported")
+		}
+		var gids []uint32
+		if layer.Data.Encoding == "" {
+			for _, tile := range layer.Data.Tiles {
+				gids = append(gids, tile.GID)
+			}
+		} else {
+			var err error
+			if gids, err = decodeTiledData(layer.Data.Encoding, layer.Data.Compression, layer.Data.Text); err != nil {
+				return err
+			}
+		}
+		if len(gids) != layer.Width*layer.Height {
+			return fmt.Errorf("level: layer %s has %d tiles instead of %d", layer.Name, len(gids), layer.Width*layer.Height)
+		}
+		level.Width, level.Height = layer.Width, layer.Height
+		level.Tiles = tiledTiles(gids, tilesets)
+	}
+	for _, group := range layers.ObjectGroups {
+		for _, object := range group.Objects {
+			objectType := object.Class
+			if objectType == "" {
+				objectType = object.Type
+			}
+			level.Objects = append(level.Objects, Object{
+				Name:       object.Name,
+				Type:       objectType,
+				X:          int(math.Round(object.X)),
+				Y:          int(math.Round(object.Y)),
+				Width:      int(math.Round(object.Width)),
+				Height:     int(math.Round(object.Height)),
+				Properties: tiledXMLProperties(object.Properties),
+			})
+		}
+	}
+	for index := range layers.Groups {
+		if err := layers.Groups[index].collect(level, tilesets, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTiledXML loads a level from a Tiled map in the XML format.
+func loadTiledXML(path string, options *LoadOptions) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tiledMap tiledXMLMap
+	if err := xml.Unmarshal(data, &tiledMap); err != nil {
+		return nil, fmt.Errorf("level: %s: %w", path, err)
+	}
+
+	tilesets := make([]*tiledTileset, 0, len(tiledMap.Tilesets))
+	for _, tileset := range tiledMap.Tilesets {
+		loaded, err := loadTiledXMLTileset(filepath.Dir(path), tileset)
+		if err != nil {
+			return nil, err
+		}
+		tilesets = append(tilesets, loaded)
+	}
+
+	flags, err := arrangeTiledTilesets(tilesets)
+	if err != nil {
+		return nil, err
+	}
+	level := &Level{Flags: flags}
+	if err := tiledMap.collect(level, tilesets, options); err != nil {
+		return nil, err
+	}
+	if level.Tiles == nil {
+		return nil, errors.New("level: the map has no matching tile layer")
+	}
+	return level, nil
+}
+
+type tiledJSONProperty struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// tiledJSONProperties transforms custom properties from a Tiled JSON file.
+func tiledJSONProperties(jsonProperties []tiledJSONProperty) []Property {
+	properties := make([]Property, 0, len(jsonProperties))
+	for _, property := range jsonProperties {
+		properties = append(properties, Property{property.Name, tiledJSONValue(property.Type, property.Value)})
+	}
+	return properties
+}
+
+type tiledJSONTileset struct {
+	FirstGID  int    `json:"firstgid"`
+	Source    string `json:"source"`
+	TileCount int    `json:"tilecount"`
+	Columns   int    `json:"columns"`
+	Tiles     []struct {
+		ID         int                 `json:"id"`
+		Properties []tiledJSONProperty `json:"properties"`
+	} `json:"tiles"`
+}
+
+type tiledJSONLayer struct {
+	Type        string          `json:"type"`
+	Name        string          `json:"name"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Encoding    string          `json:"encoding"`
+	Compression string          `json:"compression"`
+	Data        json.RawMessage `json:"data"`
+	Chunks      json.RawMessage `json:"chunks"`
+	Objects     []struct {
+		Name       string              `json:"name"`
+		Type       string              `json:"type"`
+		Class      string              `json:"class"`
+		X          float64             `json:"x"`
+		Y          float64             `json:"y"`
+		Width      float64             `json:"width"`
+		Height     float64             `json:"height"`
+		Properties []tiledJSONProperty `json:"properties"`
+	} `json:"objects"`
+	Layers []tiledJSONLayer `json:"layers"`
+}
+
+type tiledJSONMap struct {
+	Tilesets []tiledJSONTileset `json:"tilesets"`
+	Layers   []tiledJSONLayer   `json:"layers"`
+}
+
+// loadTiledJSONTileset loads a tileset from a Tiled JSON map, or from the external file that it refers to.
+func loadTiledJSONTileset(directory string, tileset tiledJSONTileset) (*tiledTileset, error) {
+	if tileset.Source != "" {
+		firstGID := tileset.FirstGID
+		if strings.ToLower(filepath.Ext(tileset.Source)) == ".tsx" {
+			return loadTiledXMLTileset(directory, tiledXMLTileset{FirstGID: firstGID, Source: tileset.Source})
+		}
+		data, err := os.ReadFile(filepath.Join(directory, tileset.Source))
+		if err != nil {
+			return nil, err
+		}
+		source := tileset.Source
+		tileset = tiledJSONTileset{}
+		if err := json.Unmarshal(data, &tileset); err != nil {
+			return nil, fmt.Errorf("level: %s: %w", source, err)
+		}
+		tileset.FirstGID = firstGID
+	}
+
+	loaded := &tiledTileset{tileset.FirstGID, editorTileset{columns: tileset.Columns, count: tileset.TileCount, flags: make(map[int]byte)}}
+	for _, tile := range tileset.Tiles {
+		if tile.ID >= loaded.count {
+			loaded.count = tile.ID + 1
+		}
+		for _, property := range tiledJSONProperties(tile.Properties) {
+			if flags, ok := flagsFromProperty(property.Name, property.Value); ok {
+				loaded.flags[tile.ID] |= flags
+			}
+		}
+	}
+	return loaded, nil
+}
+
+// collect adds the tile layer and objects of the layer, and of the layers within it, to the level.
+func (layer *tiledJSONLayer) collect(level *Level, tilesets []*tiledTileset, options *LoadOptions) error {
+	switch layer.Type {
+	case "tilelayer":
+		if level.Tiles != nil || (options.layer != "" && layer.Name != options.layer) {
+			return nil
+		}
+		if len(layer.Chunks) > 0 {
+			return errors.New("level: infinite maps are not supported")
+		}
+		var gids []uint32
+		if layer.Encoding == "base64" {
+			var data string
+			if err := json.Unmarshal(layer.Data, &data); err != nil {
+				return fmt.Errorf("level: layer %s: %w", layer.Name, err)
+			}
+			var err error
+			if gids, err = decodeTiledData(layer.Encoding, layer.Compression, data); err != nil {
+				return err
+			}
+		} else if err := json.Unmarshal(layer.Data, &gids); err != nil {
+			return fmt.Errorf("level: layer %s: %w", layer.Name, err)
+		}
+		if len(gids) != layer.Width*layer.Height {
+			return fmt.Errorf("level: layer %s has %d tiles instead of %d", layer.Name, len(gids), layer.Width*layer.Height)
+		}
+		level.Width, level.Height = layer.Width, layer.Height
+		level.Tiles = tiledTiles(gids, tilesets)
+
+	case "objectgroup":
+		for _, object := range layer.Objects {
+			objectType := object.Class
+			if objectType == "" {
+				objectType = object.Type
+			}
+			level.Objects = append(level.Objects, Object{
+				Name:       object.Name,
+				Type:       objectType,
+				X:          int(math.Round(object.X)),
+				Y:          int(math.Round(object.Y)),
+				Width:      int(math.Round(object.Width)),
+				Height:     int(math.Round(object.Height)),
+				Properties: tiledJSONProperties(object.Properties),
+			})
+		}
+
+	case "group":
+		for index := range layer.Layers {
+			if err := layer.Layers[index].collect(level, tilesets, options); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadTiledJSON loads a level from a Tiled map in the JSON format.
+func loadTiledJSON(path string, options *LoadOptions) (*Level, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var tiledMap tiledJSONMap
+	if err := json.Unmarshal(data, &tiledMap); err != nil {
+		return nil, fmt.Errorf("level: %s: %w", path, err)
+	}
+
+	tilesets := make([]*tiledTileset, 0, len(tiledMap.Tilesets))
+	for _, tileset := range tiledMap.Tilesets {
+		loaded, err := loadTiledJSONTileset(filepath.Dir(path), tileset)
+		if err != nil {
+			return nil, err
+		}
+		tilesets = append(tilesets, loaded)
+	}
+
+	flags, err := arrangeTiledTilesets(tilesets)
+	if err != nil {
+		return nil, err
+	}
+	level := &Level{Flags: flags}
+	for index := range tiledMap.Layers {
+		if err := tiledMap.Layers[index].collect(level, tilesets, options); err != nil {
+			return nil, err
+		}
+	}
+	if level.Tiles == nil {
+		return nil, errors.New("level: the map has no matching tile layer")
+	}
+	return level, nil
+}
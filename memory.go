@@ -0,0 +1,859 @@
+package tic80
+
+import "unsafe"
+
+// Memory Addresses
+const (
+	ADDRESS_SCREEN            = 0x00000
+	ADDRESS_PALETTE           = 0x03FC0
+	ADDRESS_PALETTE_MAP       = 0x03FF0
+	ADDRESS_BORDER            = 0x03FF8
+	ADDRESS_SCREEN_OFFSET     = 0x03FF9
+	ADDRESS_CURSOR            = 0x03FFB
+	ADDRESS_BLIT_SEGMENT      = 0x03FFC
+	ADDRESS_TILES             = 0x04000
+	ADDRESS_SPRITES           = 0x06000
+	ADDRESS_MAP               = 0x08000
+	ADDRESS_GAMEPADS          = 0x0FF80
+	ADDRESS_MOUSE             = 0x0FF84
+	ADDRESS_KEYBOARD          = 0x0FF88
+	ADDRESS_SOUND_REGISTERS   = 0x0FF9C
+	ADDRESS_WAVEFORMS         = 0x0FFE4
+	ADDRESS_SOUND_EFFECTS     = 0x100E4
+	ADDRESS_MUSIC_PATTERNS    = 0x11164
+	ADDRESS_MUSIC_TRACKS      = 0x13E64
+	ADDRESS_MUSIC_STATE       = 0x13FFC
+	ADDRESS_STEREO_VOLUME     = 0x14000
+	ADDRESS_PERSISTENT_MEMORY = 0x14004
+	ADDRESS_SPRITE_FLAGS      = 0x14404
+	ADDRESS_FONT              = 0x14604
+)
+
+// Screen Dimensions
+const (
+	SCREEN_WIDTH  = 240
+	SCREEN_HEIGHT = 136
+)
+
+// Map Dimensions
+const (
+	MAP_WIDTH  = 240
+	MAP_HEIGHT = 136
+)
+
+// Memory Views
+var (
+	SCREEN            = (*ScreenMemory)(ioAddress(ADDRESS_SCREEN))
+	PALETTE           = (*PaletteMemory)(ioAddress(ADDRESS_PALETTE))
+	PALETTE_MAP       = (*PaletteMapMemory)(ioAddress(ADDRESS_PALETTE_MAP))
+	BORDER            = (*BorderMemory)(ioAddress(ADDRESS_BORDER))
+	SCREEN_OFFSET     = (*ScreenOffsetMemory)(ioAddress(ADDRESS_SCREEN_OFFSET))
+	CURSOR            = (*CursorMemory)(ioAddress(ADDRESS_CURSOR))
+	BLIT_SEGMENT      = (*BlitSegmentMemory)(ioAddress(ADDRESS_BLIT_SEGMENT))
+	TILES             = (*TileMemory)(ioAddress(ADDRESS_TILES))
+	SPRITES           = (*TileMemory)(ioAddress(ADDRESS_SPRITES))
+	MAP               = (*MapMemory)(ioAddress(ADDRESS_MAP))
+	GAMEPADS          = (*GamepadMemory)(ioAddress(ADDRESS_GAMEPADS))
+	MOUSE             = (*MouseMemory)(ioAddress(ADDRESS_MOUSE))
+	KEYBOARD          = (*KeyboardMemory)(ioAddress(ADDRESS_KEYBOARD))
+	SOUND_REGISTERS   = (*SoundRegisterMemory)(ioAddress(ADDRESS_SOUND_REGISTERS))
+	WAVEFORMS         = (*WaveformMemory)(ioAddress(ADDRESS_WAVEFORMS))
+	SOUND_EFFECTS     = (*SoundEffectMemory)(ioAddress(ADDRESS_SOUND_EFFECTS))
+	MUSIC_PATTERNS    = (*MusicPatternMemory)(ioAddress(ADDRESS_MUSIC_PATTERNS))
+	MUSIC_TRACKS      = (*MusicTrackMemory)(ioAddress(ADDRESS_MUSIC_TRACKS))
+	MUSIC_STATE       = (*MusicStateMemory)(ioAddress(ADDRESS_MUSIC_STATE))
+	STEREO_VOLUME     = (*StereoVolumeMemory)(ioAddress(ADDRESS_STEREO_VOLUME))
+	PERSISTENT_MEMORY = (*PersistentMemory)(ioAddress(ADDRESS_PERSISTENT_MEMORY))
+	SPRITE_FLAGS      = (*SpriteFlagMemory)(ioAddress(ADDRESS_SPRITE_FLAGS))
+	FONT              = (*FontMemory)(ioAddress(ADDRESS_FONT))
+)
+
+// ioAddress returns a pointer to the given address of [tic80.IO_RAM].
+func ioAddress(address int) unsafe.Pointer {
+	return unsafe.Add(unsafe.Pointer(IO_RAM), address)
+}
+
+// peekNybble reads the nybble at the given index from a buffer of packed nybbles.
+func peekNybble(buffer []byte, index int) int {
+	if index%2 == 0 {
+		return int(buffer[index/2] & 0x0F)
+	}
+	return int(buffer[index/2] >> 4)
+}
+
+// pokeNybble writes the nybble at the given index to a buffer of packed nybbles.
+func pokeNybble(buffer []byte, index, value int) {
+	if index%2 == 0 {
+		buffer[index/2] = buffer[index/2]&0xF0 | byte(value&0x0F)
+	} else {
+		buffer[index/2] = buffer[index/2]&0x0F | byte(value&0x0F)<<4
+	}
+}
+
+// wrap returns value modulo size, in the range of 0 to size-1.
+// The views wrap their indices with it, so that negative indices count back from the end rather than panic.
+func wrap(value, size int) int {
+	value %= size
+	if value < 0 {
+		value += size
+	}
+	return value
+}
+
+// ScreenMemory is a view of the screen in VRAM, stored as 4 bits per pixel.
+type ScreenMemory [SCREEN_WIDTH * SCREEN_HEIGHT / 2]byte
+
+// Pixel returns the color of the pixel at the given coordinates, or 0 if they are off the screen.
+func (screen *ScreenMemory) Pixel(x, y int) int {
+	if x < 0 || x >= SCREEN_WIDTH || y < 0 || y >= SCREEN_HEIGHT {
+		return 0
+	}
+	return peekNybble(screen[:], y*SCREEN_WIDTH+x)
+}
+
+// SetPixel sets the color of the pixel at the given coordinates, unless they are off the screen.
+func (screen *ScreenMemory) SetPixel(x, y, color int) {
+	if x < 0 || x >= SCREEN_WIDTH || y < 0 || y >= SCREEN_HEIGHT {
+		return
+	}
+	pokeNybble(screen[:], y*SCREEN_WIDTH+x, color)
+}
+
+// PaletteMemory is a view of the 16 colors of the palette in VRAM, stored as 24-bit RGB.
+type PaletteMemory [16 * 3]byte
+
+// Color returns the components of the specified color.
+func (palette *PaletteMemory) Color(color int) (red, green, blue byte) {
+	color = wrap(color, 16)
+	return palette[color*3], palette[color*3+1], palette[color*3+2]
+}
+
+// SetColor sets the components of the specified color.
+func (palette *PaletteMemory) SetColor(color int, red, green, blue byte) {
+	color = wrap(color, 16)
+	palette[color*3] = red
+	palette[color*3+1] = green
+	palette[color*3+2] = blue
+}
+
+// PaletteMapMemory is a view of the palette map in VRAM, which redirects each color to another at draw time.
+type PaletteMapMemory [16 / 2]byte
+
+// Color returns the color that the specified color is drawn as.
+func (paletteMap *PaletteMapMemory) Color(color int) int {
+	return peekNybble(paletteMap[:], wrap(color, 16))
+}
+
+// SetColor sets the color that the specified color is drawn as.
+func (paletteMap *PaletteMapMemory) SetColor(color, mappedColor int) {
+	pokeNybble(paletteMap[:], wrap(color, 16), mappedColor)
+}
+
+// BorderMemory is a view of the border color in VRAM.
+type BorderMemory byte
+
+// Color returns the border color.
+func (border *BorderMemory) Color() int {
+	return int(*border) % 16
+}
+
+// SetColor sets the border color.
+func (border *BorderMemory) SetColor(color int) {
+	*border = BorderMemory(wrap(color, 16))
+}
+
+// ScreenOffsetMemory is a view of the screen offset in VRAM.
+type ScreenOffsetMemory [2]int8
+
+// Offset returns the number of pixels the screen is shifted by.
+func (offset *ScreenOffsetMemory) Offset() (x, y int) {
+	return int(offset[0]), int(offset[1])
+}
+
+// SetOffset sets the number of pixels to shift the screen by.
+func (offset *ScreenOffsetMemory) SetOffset(x, y int) {
+	offset[0] = int8(x)
+	offset[1] = int8(y)
+}
+
+// CursorMemory is a view of the mouse cursor in VRAM.
+type CursorMemory byte
+
+// Cursor returns the id of the mouse cursor.
+func (cursor *CursorMemory) Cursor() int {
+	return int(*cursor)
+}
+
+// SetCursor sets the id of the mouse cursor.
+// The system cursors are 0 to 7; any other id is used as a sprite id.
+func (cursor *CursorMemory) SetCursor(id int) {
+	*cursor = CursorMemory(id)
+}
+
+// BlitSegmentMemory is a view of the blit segment in VRAM, which selects the memory used by sprite and tile drawing.
+type BlitSegmentMemory byte
+
+// Segment returns the blit segment.
+func (segment *BlitSegmentMemory) Segment() int {
+	return int(*segment)
+}
+
+// SetSegment sets the blit segment.
+func (segment *BlitSegmentMemory) SetSegment(value int) {
+	*segment = BlitSegmentMemory(value)
+}
+
+// Tile is a view of one 8x8 tile, stored as 4 bits per pixel.
+type Tile [8 * 8 / 2]byte
+
+// Pixel returns the color of the pixel at the given coordinates, or 0 if they are outside of the tile.
+func (tile *Tile) Pixel(x, y int) int {
+	if x < 0 || x >= 8 || y < 0 || y >= 8 {
+		return 0
+	}
+	return peekNybble(tile[:], y*8+x)
+}
+
+// SetPixel sets the color of the pixel at the given coordinates, unless they are outside of the tile.
+func (tile *Tile) SetPixel(x, y, color int) {
+	if x < 0 || x >= 8 || y < 0 || y >= 8 {
+		return
+	}
+	pokeNybble(tile[:], y*8+x, color)
+}
+
+// TileMemory is a view of a bank of 256 tiles or sprites.
+type TileMemory [256]Tile
+
+// Tile returns the specified tile.
+func (tiles *TileMemory) Tile(id int) *Tile {
+	return &tiles[wrap(id, 256)]
+}
+
+// MapMemory is a view of the map, stored as one tile id per cell.
+type MapMemory [MAP_WIDTH * MAP_HEIGHT]byte
+
+// Tile returns the id of the tile at the given map coordinates, or 0 if they are off the map.
+func (tileMap *MapMemory) Tile(x, y int) int {
+	if x < 0 || x >= MAP_WIDTH || y < 0 || y >= MAP_HEIGHT {
+		return 0
+	}
+	return int(tileMap[y*MAP_WIDTH+x])
+}
+
+// SetTile sets the id of the tile at the given map coordinates, unless they are off the map.
+func (tileMap *MapMemory) SetTile(x, y, id int) {
+	if x < 0 || x >= MAP_WIDTH || y < 0 || y >= MAP_HEIGHT {
+		return
+	}
+	tileMap[y*MAP_WIDTH+x] = byte(id)
+}
+
+// GamepadMemory is a view of the buttons of the four gamepads, stored as one bit per [tic80.ButtonCode].
+type GamepadMemory [4]byte
+
+// State returns the buttons of all four gamepads at once, where each [tic80.ButtonCode] is a bit.
+func (gamepads *GamepadMemory) State() uint32 {
+	return uint32(gamepads[0]) | uint32(gamepads[1])<<8 | uint32(gamepads[2])<<16 | uint32(gamepads[3])<<24
+}
+
+// Pressed returns true if the specified button is pressed; false otherwise.
+func (gamepads *GamepadMemory) Pressed(id ButtonCode) bool {
+	id = ButtonCode(wrap(int(id), 32))
+	return gamepads[id/8]&(1<<(id%8)) != 0
+}
+
+// SetPressed sets whether the specified button is pressed.
+func (gamepads *GamepadMemory) SetPressed(id ButtonCode, pressed bool) {
+	id = ButtonCode(wrap(int(id), 32))
+	if pressed {
+		gamepads[id/8] |= 1 << (id % 8)
+	} else {
+		gamepads[id/8] &^= 1 << (id % 8)
+	}
+}
+
+// MouseMemory is a view of the state of the mouse.
+type MouseMemory [4]byte
+
+// Position returns the coordinates of the mouse on the screen.
+func (mouse *MouseMemory) Position() (x, y int) {
+	return int(mouse[0]), int(mouse[1])
+}
+
+// SetPosition sets the coordinates of the mouse on the screen.
+func (mouse *MouseMemory) SetPosition(x, y int) {
+	mouse[0] = byte(x)
+	mouse[1] = byte(y)
+}
+
+// Buttons returns whether each of the mouse buttons are pressed.
+func (mouse *MouseMemory) Buttons() (left, middle, right bool) {
+	return mouse[2]&0x01 != 0, mouse[2]&0x02 != 0, mouse[2]&0x04 != 0
+}
+
+// SetButtons sets whether each of the mouse buttons are pressed.
+func (mouse *MouseMemory) SetButtons(left, middle, right bool) {
+	mouse[2] &^= 0x07
+	if left {
+		mouse[2] |= 0x01
+	}
+	if middle {
+		mouse[2] |= 0x02
+	}
+	if right {
+		mouse[2] |= 0x04
+	}
+}
+
+// Scroll returns how far the mouse wheel was scrolled.
+func (mouse *MouseMemory) Scroll() (x, y int) {
+	bits := uint16(mouse[2]) | uint16(mouse[3])<<8
+	x = int(int8(bits>>3&0x3F<<2) >> 2)
+	y = int(int8(bits>>9&0x3F<<2) >> 2)
+	return
+}
+
+// SetScroll sets how far the mouse wheel was scrolled.
+func (mouse *MouseMemory) SetScroll(x, y int) {
+	bits := uint16(mouse[2]) | uint16(mouse[3])<<8
+	bits &^= 0x3F<<3 | 0x3F<<9
+	bits |= uint16(x&0x3F)<<3 | uint16(y&0x3F)<<9
+	mouse[2] = byte(bits)
+	mouse[3] = byte(bits >> 8)
+}
+
+// KeyboardMemory is a view of the keyboard, stored as up to four keys that are pressed at once.
+type KeyboardMemory [4]byte
+
+// Keys returns the keys that are pressed.
+func (keyboard *KeyboardMemory) Keys() []KeyCode {
+	keys := make([]KeyCode, 0, len(keyboard))
+	for _, key := range keyboard {
+		if key != 0 {
+			keys = append(keys, KeyCode(key))
+		}
+	}
+	return keys
+}
+
+// Pressed returns true if the specified key is pressed; false otherwise.
+func (keyboard *KeyboardMemory) Pressed(id KeyCode) bool {
+	for _, key := range keyboard {
+		if key != 0 && KeyCode(key) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// SetKeys sets the keys that are pressed, of which only the first four are kept.
+func (keyboard *KeyboardMemory) SetKeys(keys ...KeyCode) {
+	for index := range keyboard {
+		if index < len(keys) {
+			keyboard[index] = byte(keys[index])
+		} else {
+			keyboard[index] = 0
+		}
+	}
+}
+
+// Waveform is a view of one waveform, stored as 32 4-bit samples.
+type Waveform [32 / 2]byte
+
+// Sample returns the specified sample.
+func (waveform *Waveform) Sample(index int) int {
+	return peekNybble(waveform[:], wrap(index, 32))
+}
+
+// SetSample sets the specified sample.
+func (waveform *Waveform) SetSample(index, value int) {
+	pokeNybble(waveform[:], wrap(index, 32), value)
+}
+
+// WaveformMemory is a view of the 16 waveforms used by sound effects.
+type WaveformMemory [16]Waveform
+
+// Waveform returns the specified waveform.
+func (waveforms *WaveformMemory) Waveform(id int) *Waveform {
+	return &waveforms[wrap(id, 16)]
+}
+
+// SoundRegister is a view of the registers of one sound channel.
+type SoundRegister [2 + 32/2]byte
+
+// Frequency returns the frequency of the channel in hertz.
+func (register *SoundRegister) Frequency() int {
+	return int(register[0]) | int(register[1]&0x0F)<<8
+}
+
+// SetFrequency sets the frequency of the channel in hertz.
+func (register *SoundRegister) SetFrequency(frequency int) {
+	register[0] = byte(frequency)
+	register[1] = register[1]&0xF0 | byte(frequency>>8)&0x0F
+}
+
+// Volume returns the volume of the channel.
+func (register *SoundRegister) Volume() int {
+	return int(register[1] >> 4)
+}
+
+// SetVolume sets the volume of the channel.
+func (register *SoundRegister) SetVolume(level int) {
+	register[1] = register[1]&0x0F | byte(level%16)<<4
+}
+
+// Waveform returns the waveform the channel is playing.
+func (register *SoundRegister) Waveform() *Waveform {
+	return (*Waveform)(register[2:])
+}
+
+// SetWaveform sets the waveform the channel is playing to a copy of the specified waveform.
+// A silent waveform is played as noise.
+func (register *SoundRegister) SetWaveform(waveform *Waveform) {
+	*register.Waveform() = *waveform
+}
+
+// SoundRegisterMemory is a view of the registers of the four sound channels.
+type SoundRegisterMemory [4]SoundRegister
+
+// Channel returns the registers of the specified channel.
+func (registers *SoundRegisterMemory) Channel(channel int) *SoundRegister {
+	return &registers[wrap(channel, 4)]
+}
+
+// Sound Effect Dimensions
+const (
+	SOUND_EFFECT_TICKS = 30
+)
+
+// SoundEffect is a view of one sound effect.
+type SoundEffect [SOUND_EFFECT_TICKS*2 + 2 + 4]byte
+
+// Volume returns the volume at the specified tick, where 0 is the loudest.
+func (effect *SoundEffect) Volume(tick int) int {
+	return int(effect[wrap(tick, SOUND_EFFECT_TICKS)*2] & 0x0F)
+}
+
+// SetVolume sets the volume at the specified tick, where 0 is the loudest.
+func (effect *SoundEffect) SetVolume(tick, level int) {
+	index := wrap(tick, SOUND_EFFECT_TICKS) * 2
+	effect[index] = effect[index]&0xF0 | byte(level&0x0F)
+}
+
+// Wave returns the waveform id at the specified tick.
+func (effect *SoundEffect) Wave(tick int) int {
+	return int(effect[wrap(tick, SOUND_EFFECT_TICKS)*2] >> 4)
+}
+
+// SetWave sets the waveform id at the specified tick.
+func (effect *SoundEffect) SetWave(tick, id int) {
+	index := wrap(tick, SOUND_EFFECT_TICKS) * 2
+	effect[index] = effect[index]&0x0F | byte(id&0x0F)<<4
+}
+
+// Arpeggio returns the arpeggio offset in semitones at the specified tick.
+func (effect *SoundEffect) Arpeggio(tick int) int {
+	return int(effect[wrap(tick, SOUND_EFFECT_TICKS)*2+1] & 0x0F)
+}
+
+// SetArpeggio sets the arpeggio offset in semitones at the specified tick.
+func (effect *SoundEffect) SetArpeggio(tick, offset int) {
+	index := wrap(tick, SOUND_EFFECT_TICKS)*2 + 1
+	effect[index] = effect[index]&0xF0 | byte(offset&0x0F)
+}
+
+// Pitch returns the signed pitch offset at the specified tick.
+func (effect *SoundEffect) Pitch(tick int) int {
+	return int(int8(effect[wrap(tick, SOUND_EFFECT_TICKS)*2+1]) >> 4)
+}
+
+// SetPitch sets the signed pitch offset at the specified tick.
+func (effect *SoundEffect) SetPitch(tick, offset int) {
+	index := wrap(tick, SOUND_EFFECT_TICKS)*2 + 1
+	effect[index] = effect[index]&0x0F | byte(offset&0x0F)<<4
+}
+
+// Octave returns the base octave.
+func (effect *SoundEffect) Octave() int {
+	return int(effect[60] & 0x07)
+}
+
+// SetOctave sets the base octave.
+func (effect *SoundEffect) SetOctave(octave int) {
+	effect[60] = effect[60]&^0x07 | byte(octave&0x07)
+}
+
+// Speed returns the signed speed.
+func (effect *SoundEffect) Speed() int {
+	return int(int8(effect[60]<<1) >> 5)
+}
+
+// SetSpeed sets the signed speed.
+func (effect *SoundEffect) SetSpeed(speed int) {
+	effect[60] = effect[60]&^0x70 | byte(speed&0x07)<<4
+}
+
+// Note returns the base note.
+func (effect *SoundEffect) Note() SoundEffectNote {
+	return SoundEffectNote(effect[61] & 0x0F)
+}
+
+// SetNote sets the base note.
+func (effect *SoundEffect) SetNote(note SoundEffectNote) {
+	effect[61] = effect[61]&0xF0 | byte(wrap(int(note), 12))
+}
+
+// Pitch16x returns true if the pitch offsets are multiplied by 16; false otherwise.
+func (effect *SoundEffect) Pitch16x() bool {
+	return effect[60]&0x08 != 0
+}
+
+// SetPitch16x sets whether the pitch offsets are multiplied by 16.
+func (effect *SoundEffect) SetPitch16x(value bool) {
+	if value {
+		effect[60] |= 0x08
+	} else {
+		effect[60] &^= 0x08
+	}
+}
+
+// Reversed returns true if the arpeggio offsets are subtracted rather than added; false otherwise.
+func (effect *SoundEffect) Reversed() bool {
+	return effect[60]&0x80 != 0
+}
+
+// SetReversed sets whether the arpeggio offsets are subtracted rather than added.
+func (effect *SoundEffect) SetReversed(value bool) {
+	if value {
+		effect[60] |= 0x80
+	} else {
+		effect[60] &^= 0x80
+	}
+}
+
+// Muted returns whether each of the left and right speakers are muted.
+func (effect *SoundEffect) Muted() (left, right bool) {
+	return effect[61]&0x10 != 0, effect[61]&0x20 != 0
+}
+
+// SetMuted sets whether each of the left and right speakers are muted.
+func (effect *SoundEffect) SetMuted(left, right bool) {
+	effect[61] &^= 0x30
+	if left {
+		effect[61] |= 0x10
+	}
+	if right {
+		effect[61] |= 0x20
+	}
+}
+
+// SoundEffectEnvelope is an enumeration of the envelopes of a sound effect, which each have their own loop.
+type SoundEffectEnvelope int
+
+// Sound Effect Envelopes
+const (
+	ENVELOPE_WAVE SoundEffectEnvelope = iota
+	ENVELOPE_VOLUME
+	ENVELOPE_ARPEGGIO
+	ENVELOPE_PITCH
+)
+
+// Loop returns the first tick and the number of ticks of the loop of the specified envelope.
+// A size of 0 means that the envelope does not loop.
+func (effect *SoundEffect) Loop(envelope SoundEffectEnvelope) (start, size int) {
+	loop := effect[62+wrap(int(envelope), 4)]
+	return int(loop & 0x0F), int(loop >> 4)
+}
+
+// SetLoop sets the first tick and the number of ticks of the loop of the specified envelope.
+// A size of 0 means that the envelope does not loop.
+func (effect *SoundEffect) SetLoop(envelope SoundEffectEnvelope, start, size int) {
+	effect[62+wrap(int(envelope), 4)] = byte(start&0x0F) | byte(size&0x0F)<<4
+}
+
+// SoundEffectMemory is a view of the 64 sound effects.
+type SoundEffectMemory [64]SoundEffect
+
+// SoundEffect returns the specified sound effect.
+func (effects *SoundEffectMemory) SoundEffect(id int) *SoundEffect {
+	return &effects[wrap(id, 64)]
+}
+
+// Music Dimensions
+const (
+	MUSIC_PATTERN_COUNT = 60
+	MUSIC_TRACK_COUNT   = 8
+	MUSIC_ROWS          = 64
+	MUSIC_FRAMES        = 16
+	MUSIC_CHANNELS      = 4
+)
+
+// MusicCommand is an enumeration of the commands of a music row, which take an argument of two values, x and y.
+type MusicCommand int
+
+// Music Commands
+const (
+	MUSIC_COMMAND_NONE    MusicCommand = iota
+	MUSIC_COMMAND_VOLUME               // M: sets the volume of the left (x) and right (y) speakers.
+	MUSIC_COMMAND_CHORD                // C: cycles the note through the note, the note plus x, and the note plus y semitones.
+	MUSIC_COMMAND_JUMP                 // J: jumps to frame x and row y.
+	MUSIC_COMMAND_SLIDE                // S: slides from the previous note over the argument in ticks.
+	MUSIC_COMMAND_PITCH                // P: offsets the pitch by the argument minus 128.
+	MUSIC_COMMAND_VIBRATO              // V: vibrates the pitch with period x and depth y.
+	MUSIC_COMMAND_DELAY                // D: delays the note by the argument in ticks.
+)
+
+// MusicRow is a view of one row of a music pattern.
+type MusicRow [3]byte
+
+// Note returns the raw note of the row, where 0 is empty, 1 stops the channel, and 4 to 15 are [tic80.NOTE_C] to [tic80.NOTE_B].
+func (row *MusicRow) Note() int {
+	return int(row[0] & 0x0F)
+}
+
+// SetNote sets the raw note of the row.
+func (row *MusicRow) SetNote(note int) {
+	row[0] = row[0]&0xF0 | byte(note&0x0F)
+}
+
+// Argument returns the argument of the command of the row.
+func (row *MusicRow) Argument() int {
+	return int(row[0]&0xF0) | int(row[1]&0x0F)
+}
+
+// SetArgument sets the argument of the command of the row.
+func (row *MusicRow) SetArgument(argument int) {
+	row[0] = row[0]&0x0F | byte(argument)&0xF0
+	row[1] = row[1]&0xF0 | byte(argument)&0x0F
+}
+
+// Command returns the command of the row.
+func (row *MusicRow) Command() MusicCommand {
+	return MusicCommand(row[1] >> 4 & 0x07)
+}
+
+// SetCommand sets the command of the row.
+func (row *MusicRow) SetCommand(command MusicCommand) {
+	row[1] = row[1]&^0x70 | byte(command&0x07)<<4
+}
+
+// SoundEffect returns the id of the sound effect the row plays.
+func (row *MusicRow) SoundEffect() int {
+	return int(row[1]>>7)<<5 | int(row[2]&0x1F)
+}
+
+// SetSoundEffect sets the id of the sound effect the row plays.
+func (row *MusicRow) SetSoundEffect(id int) {
+	id = wrap(id, 64)
+	row[1] = row[1]&0x7F | byte(id>>5)<<7
+	row[2] = row[2]&0xE0 | byte(id&0x1F)
+}
+
+// Octave returns the octave of the note of the row.
+func (row *MusicRow) Octave() int {
+	return int(row[2] >> 5)
+}
+
+// SetOctave sets the octave of the note of the row.
+func (row *MusicRow) SetOctave(octave int) {
+	row[2] = row[2]&0x1F | byte(octave&0x07)<<5
+}
+
+// MusicPattern is a view of one music pattern.
+type MusicPattern [MUSIC_ROWS]MusicRow
+
+// Row returns the specified row.
+func (pattern *MusicPattern) Row(index int) *MusicRow {
+	return &pattern[wrap(index, MUSIC_ROWS)]
+}
+
+// MusicPatternMemory is a view of the music patterns.
+type MusicPatternMemory [MUSIC_PATTERN_COUNT]MusicPattern
+
+// Pattern returns the specified pattern, numbered from 1 as they are in [tic80.MusicTrack].
+// Like the other views, ids out of range wrap around, so 0, which a track uses for no pattern, is the last pattern.
+func (patterns *MusicPatternMemory) Pattern(id int) *MusicPattern {
+	return &patterns[wrap(id-1, MUSIC_PATTERN_COUNT)]
+}
+
+// MusicTrack is a view of one music track.
+type MusicTrack [MUSIC_FRAMES*3 + 3]byte
+
+// Pattern returns the id of the pattern played by the specified channel at the specified frame, or 0 if there is none.
+func (track *MusicTrack) Pattern(frame, channel int) int {
+	frame = wrap(frame, MUSIC_FRAMES)
+	channel = wrap(channel, MUSIC_CHANNELS)
+	bits := uint32(track[frame*3]) | uint32(track[frame*3+1])<<8 | uint32(track[frame*3+2])<<16
+	return int(bits >> (channel * 6) & 0x3F)
+}
+
+// SetPattern sets the id of the pattern played by the specified channel at the specified frame, or 0 for none.
+func (track *MusicTrack) SetPattern(frame, channel, id int) {
+	frame = wrap(frame, MUSIC_FRAMES)
+	channel = wrap(channel, MUSIC_CHANNELS)
+	bits := uint32(track[frame*3]) | uint32(track[frame*3+1])<<8 | uint32(track[frame*3+2])<<16
+	bits = bits&^(0x3F<<(channel*6)) | uint32(id&0x3F)<<(channel*6)
+	track[frame*3] = byte(bits)
+	track[frame*3+1] = byte(bits >> 8)
+	track[frame*3+2] = byte(bits >> 16)
+}
+
+// Tempo returns the tempo in beats per minute.
+func (track *MusicTrack) Tempo() int {
+	return int(int8(track[MUSIC_FRAMES*3])) + 150
+}
+
+// SetTempo sets the tempo in beats per minute.
+func (track *MusicTrack) SetTempo(tempo int) {
+	track[MUSIC_FRAMES*3] = byte(int8(tempo - 150))
+}
+
+// Rows returns the number of rows played from each pattern.
+func (track *MusicTrack) Rows() int {
+	return MUSIC_ROWS - int(track[MUSIC_FRAMES*3+1])
+}
+
+// SetRows sets the number of rows played from each pattern.
+func (track *MusicTrack) SetRows(rows int) {
+	track[MUSIC_FRAMES*3+1] = byte(MUSIC_ROWS - rows)
+}
+
+// Speed returns the speed.
+func (track *MusicTrack) Speed() int {
+	return int(int8(track[MUSIC_FRAMES*3+2])) + 6
+}
+
+// SetSpeed sets the speed.
+func (track *MusicTrack) SetSpeed(speed int) {
+	track[MUSIC_FRAMES*3+2] = byte(int8(speed - 6))
+}
+
+// MusicTrackMemory is a view of the music tracks.
+type MusicTrackMemory [MUSIC_TRACK_COUNT]MusicTrack
+
+// Track returns the specified track.
+func (tracks *MusicTrackMemory) Track(id int) *MusicTrack {
+	return &tracks[wrap(id, MUSIC_TRACK_COUNT)]
+}
+
+// MusicStateMemory is a view of the state of the music being played.
+type MusicStateMemory [4]byte
+
+// Track returns the track being played, or -1 if none is.
+func (state *MusicStateMemory) Track() int {
+	return int(int8(state[0]))
+}
+
+// Frame returns the frame being played, or -1 if none is.
+func (state *MusicStateMemory) Frame() int {
+	return int(int8(state[1]))
+}
+
+// Row returns the row being played, or -1 if none is.
+func (state *MusicStateMemory) Row() int {
+	return int(int8(state[2]))
+}
+
+// Looping returns true if the track being played loops; false otherwise.
+func (state *MusicStateMemory) Looping() bool {
+	return state[3]&0x01 != 0
+}
+
+// Sustaining returns true if the notes of the track being played sustain across frames; false otherwise.
+func (state *MusicStateMemory) Sustaining() bool {
+	return state[3]&0x02 != 0
+}
+
+// Playing returns true if the track is playing, as opposed to stopped or paused; false otherwise.
+func (state *MusicStateMemory) Playing() bool {
+	return state[3]>>2&0x03 != 0
+}
+
+// SetPlaying sets whether the track is playing, which pauses it without losing its position when false.
+func (state *MusicStateMemory) SetPlaying(playing bool) {
+	state[3] &^= 0x0C
+	if playing {
+		state[3] |= 0x08
+	}
+}
+
+// StereoVolumeMemory is a view of the volume of the left and right speakers of each of the four sound channels.
+type StereoVolumeMemory [4]byte
+
+// Volume returns the volume of the left and right speakers of the specified channel.
+func (stereo *StereoVolumeMemory) Volume(channel int) (left, right int) {
+	channel = wrap(channel, 4)
+	return peekNybble(stereo[:], channel*2), peekNybble(stereo[:], channel*2+1)
+}
+
+// SetVolume sets the volume of the left and right speakers of the specified channel.
+func (stereo *StereoVolumeMemory) SetVolume(channel, left, right int) {
+	channel = wrap(channel, 4)
+	pokeNybble(stereo[:], channel*2, left)
+	pokeNybble(stereo[:], channel*2+1, right)
+}
+
+// PersistentMemory is a view of the 256 values of persistent memory.
+type PersistentMemory [256 * 4]byte
+
+// Value returns the specified value.
+func (persistent *PersistentMemory) Value(index int) uint32 {
+	index = wrap(index, 256) * 4
+	return uint32(persistent[index]) | uint32(persistent[index+1])<<8 | uint32(persistent[index+2])<<16 | uint32(persistent[index+3])<<24
+}
+
+// SetValue sets the specified value.
+func (persistent *PersistentMemory) SetValue(index int, value uint32) {
+	index = wrap(index, 256) * 4
+	persistent[index] = byte(value)
+	persistent[index+1] = byte(value >> 8)
+	persistent[index+2] = byte(value >> 16)
+	persistent[index+3] = byte(value >> 24)
+}
+
+// SpriteFlagMemory is a view of the 8 flags of each of the 512 tiles and sprites.
+type SpriteFlagMemory [512]byte
+
+// Flag returns the status of the specified flag of the specified sprite.
+func (flags *SpriteFlagMemory) Flag(sprite, flag int) bool {
+	return flags[wrap(sprite, 512)]&(1<<wrap(flag, 8)) != 0
+}
+
+// SetFlag sets the status of the specified flag of the specified sprite.
+func (flags *SpriteFlagMemory) SetFlag(sprite, flag int, value bool) {
+	if value {
+		flags[wrap(sprite, 512)] |= 1 << wrap(flag, 8)
+	} else {
+		flags[wrap(sprite, 512)] &^= 1 << wrap(flag, 8)
+	}
+}
+
+// Font Dimensions
+const (
+	ALTERNATE_FONT_OFFSET = 128
+)
+
+// FontMemory is a view of the system fonts, stored as 256 8x8 characters of 1 bit per pixel.
+// The large font is stored first, and the small font after it, from [tic80.ALTERNATE_FONT_OFFSET].
+type FontMemory [256 * 8]byte
+
+// Pixel returns true if the pixel at the given coordinates of the specified character is set; false otherwise.
+func (font *FontMemory) Pixel(character byte, x, y int) bool {
+	if x < 0 || x >= 8 || y < 0 || y >= 8 {
+		return false
+	}
+	return font[int(character)*8+y]&(1<<x) != 0
+}
+
+// SetPixel sets the pixel at the given coordinates of the specified character.
+func (font *FontMemory) SetPixel(character byte, x, y int, value bool) {
+	if x < 0 || x >= 8 || y < 0 || y >= 8 {
+		return
+	}
+	if value {
+		font[int(character)*8+y] |= 1 << x
+	} else {
+		font[int(character)*8+y] &^= 1 << x
+	}
+}
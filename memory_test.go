@@ -0,0 +1,76 @@
+//go:build !tinygo
+
+package tic80
+
+import "testing"
+
+func TestWrap(t *testing.T) {
+	for _, test := range []struct{ value, size, expected int }{
+		{0, 16, 0},
+		{15, 16, 15},
+		{16, 16, 0},
+		{-1, 16, 15},
+		{-16, 16, 0},
+		{-17, 16, 15},
+	} {
+		if actual := wrap(test.value, test.size); actual != test.expected {
+			t.Errorf("wrap(%d, %d) = %d, want %d", test.value, test.size, actual, test.expected)
+		}
+	}
+}
+
+// TestMemoryNegativeIndices checks that the views wrap negative indices around from the end rather than panic.
+func TestMemoryNegativeIndices(t *testing.T) {
+	var tiles TileMemory
+	if tiles.Tile(-1) != &tiles[255] {
+		t.Error("Tile(-1) is not the last tile")
+	}
+
+	var palette PaletteMemory
+	palette.SetColor(-1, 1, 2, 3)
+	if red, green, blue := palette.Color(15); red != 1 || green != 2 || blue != 3 {
+		t.Errorf("Color(15) = %d, %d, %d after SetColor(-1, 1, 2, 3)", red, green, blue)
+	}
+
+	var gamepads GamepadMemory
+	gamepads.SetPressed(-1, true)
+	if !gamepads.Pressed(31) || gamepads.State() != 1<<31 {
+		t.Errorf("State() = %#x after SetPressed(-1, true)", gamepads.State())
+	}
+
+	var effect SoundEffect
+	effect.SetVolume(-1, 7)
+	if effect.Volume(SOUND_EFFECT_TICKS-1) != 7 {
+		t.Error("SetVolume(-1, 7) did not set the last tick")
+	}
+	effect.SetNote(-1)
+	if effect.Note() != NOTE_B {
+		t.Errorf("Note() = %d after SetNote(-1), want %d", effect.Note(), NOTE_B)
+	}
+
+	var patterns MusicPatternMemory
+	if patterns.Pattern(0) != &patterns[MUSIC_PATTERN_COUNT-1] || patterns.Pattern(1) != &patterns[0] {
+		t.Error("Pattern does not number the patterns from 1 and wrap 0 to the last")
+	}
+	if patterns.Pattern(-5).Row(-1) != &patterns[MUSIC_PATTERN_COUNT-6][MUSIC_ROWS-1] {
+		t.Error("Pattern(-5).Row(-1) is not the last row of the sixth pattern from the end")
+	}
+
+	var track MusicTrack
+	track.SetPattern(-1, -1, 5)
+	if track.Pattern(MUSIC_FRAMES-1, MUSIC_CHANNELS-1) != 5 {
+		t.Error("SetPattern(-1, -1, 5) did not set the last channel of the last frame")
+	}
+
+	var flags SpriteFlagMemory
+	flags.SetFlag(-1, -1, true)
+	if !flags.Flag(511, 7) || flags[511] != 0x80 {
+		t.Errorf("flags of sprite 511 = %#x after SetFlag(-1, -1, true)", flags[511])
+	}
+
+	var persistent PersistentMemory
+	persistent.SetValue(-1, 0x01020304)
+	if persistent.Value(255) != 0x01020304 {
+		t.Error("SetValue(-1) did not set the last value")
+	}
+}
@@ -0,0 +1,309 @@
+package midi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/sorucoder/tic80"
+)
+
+// Raw values of a [tic80.MusicRow].
+const (
+	rowNoteStop          = 1
+	rowNoteFirst         = 4
+	defaultTempo         = 500000
+	defaultRowsPerBeat   = 4
+	defaultSpeed         = 6
+	minimumTempo         = 40
+	maximumTempo         = 250
+	maximumOctave        = 7
+	maximumMusicChannels = tic80.MUSIC_CHANNELS
+)
+
+// ConvertOptions provides additional options to [midi.Convert].
+type ConvertOptions struct {
+	channels     []int
+	speed        int
+	tempo        int
+	soundEffect  int
+	instruments  map[int]int
+	firstPattern int
+	volume       bool
+}
+
+var defaultConvertOptions ConvertOptions = ConvertOptions{
+	channels:     nil,
+	speed:        defaultSpeed,
+	tempo:        -1,
+	soundEffect:  0,
+	instruments:  nil,
+	firstPattern: 1,
+	volume:       true,
+}
+
+// NewConvertOptions constructs a [midi.ConvertOptions] object with the defaults.
+func NewConvertOptions() *ConvertOptions {
+	options := new(ConvertOptions)
+	*options = defaultConvertOptions
+	options.instruments = make(map[int]int)
+	return options
+}
+
+// SetChannels sets the MIDI channels, from 0 to 15, to convert into each of the four music channels.
+// By default, the first four MIDI channels to play a note are converted.
+func (options *ConvertOptions) SetChannels(channels ...int) *ConvertOptions {
+	options.channels = nil
+	for index, channel := range channels {
+		if index < maximumMusicChannels {
+			options.channels = append(options.channels, channel%16)
+		}
+	}
+	return options
+}
+
+// SetSpeed sets the speed of the track, from 1 to 31, which sets how many rows each beat is quantized to.
+// At the default speed of 6, each beat is 4 rows.
+func (options *ConvertOptions) SetSpeed(speed int) *ConvertOptions {
+	if speed < 1 {
+		options.speed = 1
+	} else if speed > 31 {
+		options.speed = 31
+	} else {
+		options.speed = speed
+	}
+	return options
+}
+
+// SetTempo sets the tempo of the track in beats per minute, instead of the first tempo of the MIDI file.
+func (options *ConvertOptions) SetTempo(tempo int) *ConvertOptions {
+	options.tempo = tempo
+	return options
+}
+
+// SetSoundEffect sets the id of the sound effect that plays the notes of MIDI programs that have no instrument set.
+func (options *ConvertOptions) SetSoundEffect(id int) *ConvertOptions {
+	options.soundEffect = id % 64
+	return options
+}
+
+// SetInstrument sets the id of the sound effect that plays the notes of the specified MIDI program.
+func (options *ConvertOptions) SetInstrument(program, id int) *ConvertOptions {
+	options.instruments[program%128] = id % 64
+	return options
+}
+
+// SetFirstPattern sets the id of the first pattern that may be written to, numbered from 1 as they are in [tic80.MusicTrack].
+func (options *ConvertOptions) SetFirstPattern(id int) *ConvertOptions {
+	options.firstPattern = id
+	return options
+}
+
+// ToggleVolume toggles whether the velocity of notes sets the volume with the master volume command.
+func (options *ConvertOptions) ToggleVolume() *ConvertOptions {
+	options.volume = !options.volume
+	return options
+}
+
+// Convert converts a MIDI file into a music track, writing the patterns it plays to the pattern memory.
+// Each of up to four MIDI channels becomes one music channel, with its notes quantized to the rows.
+// Music channels play one note at a time, so overlapping notes cut each other off.
+//
+// It returns a warning for each problem that was worked around, such as notes that were out of range or dropped.
+func Convert(file *File, track *tic80.MusicTrack, patterns *tic80.MusicPatternMemory, options *ConvertOptions) (warnings []string, err error) {
+	if options == nil {
+		options = &defaultConvertOptions
+	}
+
+	channels := options.channels
+	if channels == nil {
+		channels, warnings = noteChannels(file)
+	}
+
+	tempo := options.tempo
+	if tempo < 0 {
+		var tempoWarnings []string
+		tempo, tempoWarnings = fileTempo(file)
+		warnings = append(warnings, tempoWarnings...)
+	}
+	if tempo < minimumTempo || tempo > maximumTempo {
+		clamped := int(math.Max(minimumTempo, math.Min(maximumTempo, float64(tempo))))
+		warnings = append(warnings, fmt.Sprintf("tempo of %d BPM is out of range, so %d BPM is used instead", tempo, clamped))
+		tempo = clamped
+	}
+
+	rowsPerTick := float64(defaultRowsPerBeat*defaultSpeed) / float64(options.speed) / float64(file.Division)
+	var rows [maximumMusicChannels][]tic80.MusicRow
+	length := 0
+	for musicChannel, midiChannel := range channels {
+		var channelWarnings []string
+		rows[musicChannel], channelWarnings = convertChannel(file, midiChannel, rowsPerTick, options)
+		for _, warning := range channelWarnings {
+			warnings = append(warnings, fmt.Sprintf("MIDI channel %d: %s", midiChannel, warning))
+		}
+		if len(rows[musicChannel]) > length {
+			length = len(rows[musicChannel])
+		}
+	}
+
+	frames := (length + tic80.MUSIC_ROWS - 1) / tic80.MUSIC_ROWS
+	if frames > tic80.MUSIC_FRAMES {
+		warnings = append(warnings, fmt.Sprintf("song is %d frames long, so it was cut to %d frames", frames, tic80.MUSIC_FRAMES))
+		frames = tic80.MUSIC_FRAMES
+	}
+
+	*track = tic80.MusicTrack{}
+	track.SetTempo(tempo)
+	track.SetSpeed(options.speed)
+	track.SetRows(tic80.MUSIC_ROWS)
+	written := make(map[tic80.MusicPattern]int)
+	nextPattern := options.firstPattern
+	for frame := 0; frame < frames; frame++ {
+		for channel := range rows {
+			var pattern tic80.MusicPattern
+			for row := range pattern {
+				if index := frame*tic80.MUSIC_ROWS + row; index < len(rows[channel]) {
+					pattern[row] = rows[channel][index]
+				}
+			}
+			if pattern == (tic80.MusicPattern{}) {
+				continue
+			}
+
+			id, found := written[pattern]
+			if !found {
+				if nextPattern < 1 || nextPattern > tic80.MUSIC_PATTERN_COUNT {
+					return warnings, errors.New("midi: there are not enough patterns for the song")
+				}
+				id = nextPattern
+				nextPattern++
+				*patterns.Pattern(id) = pattern
+				written[pattern] = id
+			}
+			track.SetPattern(frame, channel, id)
+		}
+	}
+	return warnings, nil
+}
+
+// noteChannels returns the first four MIDI channels to play a note, in the order they first do.
+func noteChannels(file *File) (channels []int, warnings []string) {
+	var seen [16]bool
+	for _, event := range file.Events {
+		if event.Type != EVENT_NOTE_ON || seen[event.Channel] {
+			continue
+		}
+		seen[event.Channel] = true
+		if len(channels) < maximumMusicChannels {
+			channels = append(channels, event.Channel)
+		} else {
+			warnings = append(warnings, fmt.Sprintf("MIDI channel %d was dropped, since there are only %d music channels", event.Channel, maximumMusicChannels))
+		}
+	}
+	return
+}
+
+// fileTempo returns the first tempo of the file in beats per minute.
+func fileTempo(file *File) (tempo int, warnings []string) {
+	microseconds := -1
+	for _, event := range file.Events {
+		if event.Type != EVENT_TEMPO {
+			continue
+		}
+		if microseconds < 0 {
+			microseconds = event.Tempo
+		} else if event.Tempo != microseconds {
+			warnings = append(warnings, "tempo changes were ignored, since a track has only one tempo")
+			break
+		}
+	}
+	if microseconds <= 0 {
+		microseconds = defaultTempo
+	}
+	return int(math.Round(60000000 / float64(microseconds))), warnings
+}
+
+// convertChannel converts the notes of a MIDI channel into rows.
+func convertChannel(file *File, midiChannel int, rowsPerTick float64, options *ConvertOptions) (rows []tic80.MusicRow, warnings []string) {
+	soundEffect := options.soundEffect
+	volume := -1
+	sounding, soundingRow := -1, -1
+	outOfRange, cut, collided := 0, 0, 0
+
+	// placed records which rows start a note, as opposed to stopping one.
+	var placed []bool
+	grow := func(row int) {
+		for len(rows) <= row {
+			rows = append(rows, tic80.MusicRow{})
+			placed = append(placed, false)
+		}
+	}
+
+	for _, event := range file.Events {
+		if event.Channel != midiChannel {
+			continue
+		}
+		row := int(math.Round(float64(event.Tick) * rowsPerTick))
+
+		switch event.Type {
+		case EVENT_PROGRAM:
+			if id, found := options.instruments[event.Program]; found {
+				soundEffect = id
+			} else {
+				soundEffect = options.soundEffect
+			}
+
+		case EVENT_NOTE_ON:
+			grow(row)
+			if placed[row] {
+				collided++
+				continue
+			}
+			if sounding >= 0 {
+				cut++
+			}
+
+			octave := event.Note/12 - 1
+			if octave < 0 || octave > maximumOctave {
+				outOfRange++
+				octave = int(math.Max(0, math.Min(maximumOctave, float64(octave))))
+			}
+			rows[row] = tic80.MusicRow{}
+			rows[row].SetNote(rowNoteFirst + event.Note%12)
+			rows[row].SetOctave(octave)
+			rows[row].SetSoundEffect(soundEffect)
+			if level := event.Velocity * 15 / 127; options.volume && level != volume {
+				rows[row].SetCommand(tic80.MUSIC_COMMAND_VOLUME)
+				rows[row].SetArgument(level<<4 | level)
+				volume = level
+			}
+			placed[row] = true
+			sounding, soundingRow = event.Note, row
+
+		case EVENT_NOTE_OFF:
+			if event.Note != sounding {
+				continue
+			}
+			if row <= soundingRow {
+				// The note is shorter than a row, so it lasts until the next row.
+				row = soundingRow + 1
+			}
+			grow(row)
+			if !placed[row] && rows[row].Note() == 0 {
+				rows[row].SetNote(rowNoteStop)
+			}
+			sounding = -1
+		}
+	}
+
+	if outOfRange > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d notes were out of range, and were moved to the nearest octave", outOfRange))
+	}
+	if cut > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d notes were cut off by overlapping notes, since a music channel plays one note at a time", cut))
+	}
+	if collided > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d notes were dropped, since they were quantized to the same row as another note", collided))
+	}
+	return rows, warnings
+}
@@ -0,0 +1,192 @@
+// Package midi converts standard MIDI files into the music patterns and tracks of a cartridge.
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// EventType is an enumeration of the MIDI events used for conversion.
+type EventType int
+
+// Event Types
+const (
+	EVENT_NOTE_OFF EventType = iota
+	EVENT_NOTE_ON
+	EVENT_PROGRAM
+	EVENT_TEMPO
+)
+
+// Event is a MIDI event, at an absolute time in ticks.
+type Event struct {
+	Tick    int
+	Type    EventType
+	Channel int
+
+	// Note and Velocity are set by note events.
+	Note     int
+	Velocity int
+
+	// Program is set by program change events.
+	Program int
+
+	// Tempo is set by tempo events, in microseconds per quarter note.
+	Tempo int
+}
+
+// File is a parsed MIDI file, with the events of all of its tracks merged in order.
+type File struct {
+	// Division is the number of ticks per quarter note.
+	Division int
+	Events   []Event
+}
+
+// readVariable reads a variable-length quantity.
+func readVariable(reader *bytes.Reader) (int, error) {
+	value := 0
+	for count := 0; count < 4; count++ {
+		next, err := reader.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		value = value<<7 | int(next&0x7F)
+		if next&0x80 == 0 {
+			return value, nil
+		}
+	}
+	return 0, errors.New("midi: variable-length quantity is too long")
+}
+
+// Parse parses a standard MIDI file.
+func Parse(reader io.Reader) (*File, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 14 || string(data[:4]) != "MThd" {
+		return nil, errors.New("midi: not a standard MIDI file")
+	}
+	headerLength := int(binary.BigEndian.Uint32(data[4:8]))
+	if headerLength < 6 || len(data) < 8+headerLength {
+		return nil, errors.New("midi: truncated header")
+	}
+	division := int(binary.BigEndian.Uint16(data[12:14]))
+	if division&0x8000 != 0 {
+		return nil, errors.New("midi: SMPTE time division is not supported")
+	}
+	if division == 0 {
+		return nil, errors.New("midi: time division is zero")
+	}
+
+	file := &File{Division: division}
+	for offset := 8 + headerLength; offset+8 <= len(data); {
+		chunkType := string(data[offset : offset+4])
+		length := int(binary.BigEndian.Uint32(data[offset+4 : offset+8]))
+		offset += 8
+		if offset+length > len(data) {
+			return nil, errors.New("midi: truncated track")
+		}
+		if chunkType == "MTrk" {
+			events, err := parseTrack(data[offset : offset+length])
+			if err != nil {
+				return nil, err
+			}
+			file.Events = append(file.Events, events...)
+		}
+		offset += length
+	}
+	// Notes that end at the same time as others start are ended first, so that repeated notes are not cut short.
+	sort.SliceStable(file.Events, func(a, b int) bool {
+		if file.Events[a].Tick != file.Events[b].Tick {
+			return file.Events[a].Tick < file.Events[b].Tick
+		}
+		return file.Events[a].Type == EVENT_NOTE_OFF && file.Events[b].Type != EVENT_NOTE_OFF
+	})
+	return file, nil
+}
+
+// parseTrack parses the events of one track.
+func parseTrack(data []byte) ([]Event, error) {
+	var events []Event
+	reader := bytes.NewReader(data)
+	tick := 0
+	status := byte(0)
+	for reader.Len() > 0 {
+		delta, err := readVariable(reader)
+		if err != nil {
+			return nil, fmt.Errorf("midi: truncated event: %w", err)
+		}
+		tick += delta
+
+		next, err := reader.ReadByte()
+		if err != nil {
+			return nil, errors.New("midi: truncated event")
+		}
+		if next&0x80 != 0 {
+			status = next
+		} else {
+			// Running status reuses the previous status, and this byte is the first data byte.
+			if status == 0 {
+				return nil, errors.New("midi: running status without a previous status")
+			}
+			reader.UnreadByte()
+		}
+
+		switch {
+		case status == 0xFF:
+			metaType, err := reader.ReadByte()
+			if err != nil {
+				return nil, errors.New("midi: truncated meta event")
+			}
+			length, err := readVariable(reader)
+			if err != nil || length > reader.Len() {
+				return nil, errors.New("midi: truncated meta event")
+			}
+			meta := make([]byte, length)
+			reader.Read(meta)
+			if metaType == 0x51 && length == 3 {
+				events = append(events, Event{Tick: tick, Type: EVENT_TEMPO, Tempo: int(meta[0])<<16 | int(meta[1])<<8 | int(meta[2])})
+			}
+			status = 0
+
+		case status == 0xF0 || status == 0xF7:
+			length, err := readVariable(reader)
+			if err != nil || length > reader.Len() {
+				return nil, errors.New("midi: truncated system exclusive event")
+			}
+			reader.Seek(int64(length), io.SeekCurrent)
+			status = 0
+
+		default:
+			channel := int(status & 0x0F)
+			parameters := 2
+			if status&0xF0 == 0xC0 || status&0xF0 == 0xD0 {
+				parameters = 1
+			}
+			var values [2]byte
+			for index := 0; index < parameters; index++ {
+				if values[index], err = reader.ReadByte(); err != nil {
+					return nil, errors.New("midi: truncated channel event")
+				}
+			}
+
+			switch status & 0xF0 {
+			case 0x80:
+				events = append(events, Event{Tick: tick, Type: EVENT_NOTE_OFF, Channel: channel, Note: int(values[0])})
+			case 0x90:
+				if values[1] == 0 {
+					events = append(events, Event{Tick: tick, Type: EVENT_NOTE_OFF, Channel: channel, Note: int(values[0])})
+				} else {
+					events = append(events, Event{Tick: tick, Type: EVENT_NOTE_ON, Channel: channel, Note: int(values[0]), Velocity: int(values[1])})
+				}
+			case 0xC0:
+				events = append(events, Event{Tick: tick, Type: EVENT_PROGRAM, Channel: channel, Program: int(values[0])})
+			}
+		}
+	}
+	return events, nil
+}
@@ -0,0 +1,244 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sorucoder/tic80"
+)
+
+// smf builds a standard MIDI file with the given division and the given tracks of raw events.
+func smf(division int, tracks ...[]byte) []byte {
+	data := []byte("MThd")
+	data = binary.BigEndian.AppendUint32(data, 6)
+	data = binary.BigEndian.AppendUint16(data, 1)
+	data = binary.BigEndian.AppendUint16(data, uint16(len(tracks)))
+	data = binary.BigEndian.AppendUint16(data, uint16(division))
+	for _, track := range tracks {
+		data = append(data, "MTrk"...)
+		data = binary.BigEndian.AppendUint32(data, uint32(len(track)))
+		data = append(data, track...)
+	}
+	return data
+}
+
+func TestReadVariable(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		value    int
+		hasError bool
+	}{
+		{[]byte{0x00}, 0, false},
+		{[]byte{0x7F}, 0x7F, false},
+		{[]byte{0x81, 0x00}, 0x80, false},
+		{[]byte{0xFF, 0x7F}, 0x3FFF, false},
+		{[]byte{0x81, 0x80, 0x80, 0x00}, 0x200000, false},
+		{[]byte{0xFF, 0xFF, 0xFF, 0x7F}, 0x0FFFFFFF, false},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x00}, 0, true},
+		{[]byte{0x81}, 0, true},
+	}
+	for _, test := range tests {
+		value, err := readVariable(bytes.NewReader(test.data))
+		if (err != nil) != test.hasError || value != test.value {
+			t.Errorf("% x: read %d, %v, expected %d", test.data, value, err, test.value)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		tracks   [][]byte
+		expected []Event
+	}{
+		{"running status", [][]byte{{
+			0x00, 0x90, 60, 100,
+			0x10, 62, 90,
+			0x10, 60, 0,
+		}}, []Event{
+			{Tick: 0, Type: EVENT_NOTE_ON, Note: 60, Velocity: 100},
+			{Tick: 16, Type: EVENT_NOTE_ON, Note: 62, Velocity: 90},
+			{Tick: 32, Type: EVENT_NOTE_OFF, Note: 60},
+		}},
+		{"variable delta", [][]byte{{
+			0x81, 0x00, 0x83, 60, 0,
+			0x00, 0xC2, 5,
+			0x00, 0xD2, 64,
+			0x00, 0xB2, 7, 100,
+			0x81, 0x80, 0x00, 0x92, 61, 1,
+		}}, []Event{
+			{Tick: 128, Type: EVENT_NOTE_OFF, Channel: 3, Note: 60},
+			{Tick: 128, Type: EVENT_PROGRAM, Channel: 2, Program: 5},
+			{Tick: 128 + 16384, Type: EVENT_NOTE_ON, Channel: 2, Note: 61, Velocity: 1},
+		}},
+		{"meta and system exclusive", [][]byte{{
+			0x00, 0xFF, 0x51, 0x03, 0x07, 0xA1, 0x20,
+			0x00, 0xF0, 0x02, 0x7E, 0xF7,
+			0x00, 0xFF, 0x03, 0x02, 'h', 'i',
+			0x05, 0x80, 60, 0,
+		}}, []Event{
+			{Tick: 0, Type: EVENT_TEMPO, Tempo: 500000},
+			{Tick: 5, Type: EVENT_NOTE_OFF, Note: 60},
+		}},
+		{"merged tracks", [][]byte{
+			{0x00, 0x90, 60, 100, 0x08, 0x80, 60, 0},
+			{0x08, 0x91, 64, 100, 0x04, 0x81, 64, 0},
+		}, []Event{
+			{Tick: 0, Type: EVENT_NOTE_ON, Note: 60, Velocity: 100},
+			{Tick: 8, Type: EVENT_NOTE_OFF, Note: 60},
+			{Tick: 8, Type: EVENT_NOTE_ON, Channel: 1, Note: 64, Velocity: 100},
+			{Tick: 12, Type: EVENT_NOTE_OFF, Channel: 1, Note: 64},
+		}},
+	}
+	for _, test := range tests {
+		file, err := Parse(bytes.NewReader(smf(96, test.tracks...)))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if file.Division != 96 {
+			t.Errorf("%s: division is %d, expected 96", test.name, file.Division)
+		}
+		if !reflect.DeepEqual(file.Events, test.expected) {
+			t.Errorf("%s: events are\n%v\nexpected\n%v", test.name, file.Events, test.expected)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{"not midi", []byte("RIFF0000WAVEfmt "), "not a standard MIDI file"},
+		{"smpte", smf(0xE728), "SMPTE"},
+		{"zero division", smf(0), "time division is zero"},
+		{"truncated track", smf(96, []byte{0x00, 0x90, 60, 100})[:24], "truncated track"},
+		{"running status", smf(96, []byte{0x00, 60, 100}), "running status"},
+		{"running status after meta", smf(96, []byte{0x00, 0xFF, 0x2F, 0x00, 0x00, 60, 100}), "running status"},
+		{"truncated channel event", smf(96, []byte{0x00, 0x90, 60}), "truncated channel event"},
+		{"truncated meta event", smf(96, []byte{0x00, 0xFF, 0x51, 0x03, 0x07}), "truncated meta event"},
+		{"truncated delta", smf(96, []byte{0x80}), "truncated event"},
+	}
+	for _, test := range tests {
+		if _, err := Parse(bytes.NewReader(test.data)); err == nil || !strings.Contains(err.Error(), test.error) {
+			t.Errorf("%s: error is %v, expected one about %q", test.name, err, test.error)
+		}
+	}
+}
+
+// step is the expected content of a row of a converted pattern.
+type step struct {
+	row         int
+	note        int
+	octave      int
+	soundEffect int
+	command     tic80.MusicCommand
+	argument    int
+}
+
+func TestConvert(t *testing.T) {
+	// A quarter note of C4 at full velocity, then a quarter note of D4 at the same velocity,
+	// then an eighth note of E5 at about half velocity, on MIDI channel 0 with 96 ticks per quarter note.
+	notes := []byte{
+		0x00, 0x90, 60, 127,
+		0x60, 0x80, 60, 0,
+		0x00, 0x90, 62, 127,
+		0x60, 0x80, 62, 0,
+		0x00, 0xC0, 9,
+		0x00, 0x90, 76, 64,
+		0x30, 0x80, 76, 0,
+	}
+	volume := tic80.MUSIC_COMMAND_VOLUME
+	tests := []struct {
+		name     string
+		options  *ConvertOptions
+		speed    int
+		expected []step
+	}{
+		{"default", nil, 6, []step{
+			{0, rowNoteFirst, 4, 0, volume, 0xFF},
+			{4, rowNoteFirst + 2, 4, 0, 0, 0},
+			{8, rowNoteFirst + 4, 5, 0, volume, 0x77},
+			{10, rowNoteStop, 0, 0, 0, 0},
+		}},
+		{"half speed", NewConvertOptions().SetSpeed(3).SetInstrument(9, 12).ToggleVolume(), 3, []step{
+			{0, rowNoteFirst, 4, 0, 0, 0},
+			{8, rowNoteFirst + 2, 4, 0, 0, 0},
+			{16, rowNoteFirst + 4, 5, 12, 0, 0},
+			{20, rowNoteStop, 0, 0, 0, 0},
+		}},
+	}
+	for _, test := range tests {
+		file, err := Parse(bytes.NewReader(smf(96, append([]byte{0x00, 0xFF, 0x51, 0x03, 0x09, 0x27, 0xC0}, notes...))))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var track tic80.MusicTrack
+		var patterns tic80.MusicPatternMemory
+		warnings, err := Convert(file, &track, &patterns, test.options)
+		if err != nil || len(warnings) > 0 {
+			t.Errorf("%s: converted with %q, %v", test.name, warnings, err)
+		}
+		// 0x0927C0 microseconds per quarter note is 100 BPM.
+		if track.Tempo() != 100 || track.Speed() != test.speed || track.Pattern(0, 0) != 1 || track.Pattern(0, 1) != 0 {
+			t.Errorf("%s: track has tempo %d, speed %d and patterns %d, %d", test.name, track.Tempo(), track.Speed(), track.Pattern(0, 0), track.Pattern(0, 1))
+		}
+
+		var expected tic80.MusicPattern
+		for _, step := range test.expected {
+			row := expected.Row(step.row)
+			row.SetNote(step.note)
+			if step.note != rowNoteStop {
+				row.SetOctave(step.octave)
+				row.SetSoundEffect(step.soundEffect)
+			}
+			row.SetCommand(step.command)
+			row.SetArgument(step.argument)
+		}
+		if *patterns.Pattern(1) != expected {
+			for index := 0; index < tic80.MUSIC_ROWS; index++ {
+				if actual := patterns.Pattern(1).Row(index); *actual != *expected.Row(index) {
+					t.Errorf("%s: row %d is %v, expected %v", test.name, index, actual.Step(), expected.Row(index).Step())
+				}
+			}
+		}
+	}
+}
+
+func TestConvertWarnings(t *testing.T) {
+	// Five channels play a note each, and channel 0 plays a note below the lowest octave and overlapping notes.
+	data := smf(96, []byte{
+		0x00, 0x90, 5, 100,
+		0x00, 0x91, 60, 100,
+		0x00, 0x92, 60, 100,
+		0x00, 0x93, 60, 100,
+		0x00, 0x94, 60, 100,
+		0x60, 0x90, 60, 100,
+		0x01, 0x90, 62, 100,
+	})
+	file, err := Parse(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var track tic80.MusicTrack
+	var patterns tic80.MusicPatternMemory
+	warnings, err := Convert(file, &track, &patterns, NewConvertOptions().SetTempo(300))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"MIDI channel 4 was dropped, since there are only 4 music channels",
+		"tempo of 300 BPM is out of range, so 250 BPM is used instead",
+		"MIDI channel 0: 1 notes were out of range, and were moved to the nearest octave",
+		"MIDI channel 0: 1 notes were cut off by overlapping notes, since a music channel plays one note at a time",
+		"MIDI channel 0: 1 notes were dropped, since they were quantized to the same row as another note",
+	}
+	if !reflect.DeepEqual(warnings, expected) {
+		t.Errorf("warnings are\n%q\nexpected\n%q", warnings, expected)
+	}
+}
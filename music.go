@@ -0,0 +1,221 @@
+package tic80
+
+import "errors"
+
+// Raw notes of a [tic80.MusicRow].
+const (
+	musicNoteStop  = 1
+	musicNoteFirst = 4
+)
+
+// MusicStep is the contents of a row of a music pattern, for reading and writing it in one go with [tic80.MusicRow.Step]
+// and [tic80.MusicRow.SetStep].
+type MusicStep struct {
+	// Note is the note to play, or [tic80.NOTE_NONE] to play none.
+	Note SoundEffectNote
+
+	// Stop is true if the row stops the note being played; it is only used when Note is [tic80.NOTE_NONE].
+	Stop bool
+
+	// Octave and SoundEffect are the octave of the note and the id of the sound effect that plays it.
+	Octave      int
+	SoundEffect int
+
+	// Command and Argument are the command of the row, and its argument from 0 to 255.
+	Command  MusicCommand
+	Argument int
+}
+
+// Step returns the contents of the row.
+func (row *MusicRow) Step() MusicStep {
+	step := MusicStep{
+		Note:        NOTE_NONE,
+		Octave:      row.Octave(),
+		SoundEffect: row.SoundEffect(),
+		Command:     row.Command(),
+		Argument:    row.Argument(),
+	}
+	switch note := row.Note(); {
+	case note == musicNoteStop:
+		step.Stop = true
+	case note >= musicNoteFirst:
+		step.Note = SoundEffectNote(note - musicNoteFirst)
+	}
+	return step
+}
+
+// SetStep sets the contents of the row, unless any of its values are out of range.
+func (row *MusicRow) SetStep(step MusicStep) error {
+	switch {
+	case step.Note < NOTE_NONE || step.Note > NOTE_B:
+		return errors.New("tic80: note must be from NOTE_C to NOTE_B, or NOTE_NONE")
+	case step.Octave < 0 || step.Octave > 7:
+		return errors.New("tic80: octave must be from 0 to 7")
+	case step.SoundEffect < 0 || step.SoundEffect > 63:
+		return errors.New("tic80: sound effect must be from 0 to 63")
+	case step.Command < MUSIC_COMMAND_NONE || step.Command > MUSIC_COMMAND_DELAY:
+		return errors.New("tic80: command must be from MUSIC_COMMAND_NONE to MUSIC_COMMAND_DELAY")
+	case step.Argument < 0 || step.Argument > 255:
+		return errors.New("tic80: argument must be from 0 to 255")
+	}
+
+	*row = MusicRow{}
+	switch {
+	case step.Note != NOTE_NONE:
+		row.SetNote(int(step.Note) + musicNoteFirst)
+	case step.Stop:
+		row.SetNote(musicNoteStop)
+	}
+	row.SetOctave(step.Octave)
+	row.SetSoundEffect(step.SoundEffect)
+	row.SetCommand(step.Command)
+	row.SetArgument(step.Argument)
+	return nil
+}
+
+// Frame returns the ids of the patterns played by each channel at the specified frame, where 0 is none.
+func (track *MusicTrack) Frame(frame int) [MUSIC_CHANNELS]int {
+	var patterns [MUSIC_CHANNELS]int
+	for channel := range patterns {
+		patterns[channel] = track.Pattern(frame, channel)
+	}
+	return patterns
+}
+
+// SetFrame sets the ids of the patterns played by each channel at the specified frame, where 0 is none,
+// unless any of them are out of range.
+func (track *MusicTrack) SetFrame(frame int, patterns [MUSIC_CHANNELS]int) error {
+	if frame < 0 || frame >= MUSIC_FRAMES {
+		return errors.New("tic80: frame must be from 0 to 15")
+	}
+	for _, id := range patterns {
+		if id < 0 || id > MUSIC_PATTERN_COUNT {
+			return errors.New("tic80: pattern must be from 1 to 60, or 0 for none")
+		}
+	}
+	for channel, id := range patterns {
+		track.SetPattern(frame, channel, id)
+	}
+	return nil
+}
+
+// Length returns the number of frames that are played before the track ends or loops,
+// which is up to the first frame where no channel plays a pattern.
+func (track *MusicTrack) Length() int {
+	for frame := 0; frame < MUSIC_FRAMES; frame++ {
+		if track.Frame(frame) == [MUSIC_CHANNELS]int{} {
+			return frame
+		}
+	}
+	return MUSIC_FRAMES
+}
+
+// MusicStatus is a snapshot of the state of the music, as returned by [tic80.MusicState].
+type MusicStatus struct {
+	// Track, Frame and Row are the position of the music, or -1 if no track is playing or paused.
+	Track int
+	Frame int
+	Row   int
+
+	Looping bool
+	Playing bool
+}
+
+// MusicState returns the state of the music, read from [tic80.MUSIC_STATE].
+func MusicState() MusicStatus {
+	return MusicStatus{
+		Track:   MUSIC_STATE.Track(),
+		Frame:   MUSIC_STATE.Frame(),
+		Row:     MUSIC_STATE.Row(),
+		Looping: MUSIC_STATE.Looping(),
+		Playing: MUSIC_STATE.Playing(),
+	}
+}
+
+// IsPlaying returns true if a music track is playing, as opposed to stopped or paused; false otherwise.
+func IsPlaying() bool {
+	return MUSIC_STATE.Track() >= 0 && MUSIC_STATE.Playing()
+}
+
+// StopMusic stops the music.
+func StopMusic() {
+	Music(nil)
+}
+
+// PauseMusic pauses the music, keeping its position so that [tic80.ResumeMusic] continues it.
+func PauseMusic() {
+	MUSIC_STATE.SetPlaying(false)
+}
+
+// ResumeMusic continues the music paused with [tic80.PauseMusic].
+func ResumeMusic() {
+	if MUSIC_STATE.Track() >= 0 {
+		MUSIC_STATE.SetPlaying(true)
+	}
+}
+
+// MusicEvents calls functions as the music moves from row to row, to sync a game to its soundtrack.
+// The music is checked at the start of each frame, before [tic80.Game.Tic] is called,
+// so a tempo fast enough to play more than one row per frame only reports the last of them.
+type MusicEvents struct {
+	rowCallback  func(frame, row int)
+	beatCallback func(beat int)
+	rowsPerBeat  int
+	track        int
+	frame        int
+	row          int
+	beat         int
+}
+
+var currentMusicEvents *MusicEvents
+
+// NewMusicEvents constructs a [tic80.MusicEvents] object with no callbacks.
+func NewMusicEvents() *MusicEvents {
+	return &MusicEvents{track: -1, frame: -1, row: -1, beat: -1}
+}
+
+// SetMusicEvents sets the music events to check each frame, or nil to check none.
+func SetMusicEvents(events *MusicEvents) {
+	currentMusicEvents = events
+}
+
+// SetRowCallback sets a function to call when the music moves to a new row.
+func (events *MusicEvents) SetRowCallback(callback func(frame, row int)) *MusicEvents {
+	events.rowCallback = callback
+	return events
+}
+
+// SetBeatCallback sets a function to call when the music moves to a new beat, every specified number of rows.
+// The beat counts from 0 at the first row of the track; at the default speed of 6, a beat is 4 rows.
+func (events *MusicEvents) SetBeatCallback(rowsPerBeat int, callback func(beat int)) *MusicEvents {
+	events.rowsPerBeat = rowsPerBeat
+	events.beatCallback = callback
+	return events
+}
+
+// check calls the callbacks if the music has moved since it was last checked.
+func (events *MusicEvents) check() {
+	track, frame, row := MUSIC_STATE.Track(), MUSIC_STATE.Frame(), MUSIC_STATE.Row()
+	if track < 0 {
+		events.track, events.frame, events.row, events.beat = -1, -1, -1, -1
+		return
+	}
+	if !MUSIC_STATE.Playing() {
+		return
+	}
+	if track == events.track && frame == events.frame && row == events.row {
+		return
+	}
+	events.track, events.frame, events.row = track, frame, row
+	if events.rowCallback != nil {
+		events.rowCallback(frame, row)
+	}
+
+	if events.beatCallback != nil && events.rowsPerBeat > 0 {
+		beat := (frame*MUSIC_TRACKS.Track(track).Rows() + row) / events.rowsPerBeat
+		if beat != events.beat {
+			events.beat = beat
+			events.beatCallback(beat)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package tic80
+
+import (
+	"encoding/hex"
+	"errors"
+	"image/color"
+)
+
+// SWEETIE_16 is the default palette of TIC-80, in the hex format used by [tic80.ParsePalette].
+const SWEETIE_16 = "1a1c2c5d275db13e53ef7d57ffcd75a7f07038b76425717929366f3b5dc941a6f673eff7f4f4f494b0c2566c86333c57"
+
+// Palette is a set of 16 colors.
+type Palette [16]color.RGBA
+
+// ParsePalette parses a palette from the hex format that TIC-80 uses to import and export palettes.
+func ParsePalette(text string) (Palette, error) {
+	var palette Palette
+	if len(text) != len(palette)*3*2 {
+		return palette, errors.New("tic80: palette must be 96 hexadecimal digits")
+	}
+
+	components, err := hex.DecodeString(text)
+	if err != nil {
+		return palette, errors.New("tic80: palette contains an invalid hexadecimal digit")
+	}
+
+	for index := range palette {
+		palette[index] = color.RGBA{components[index*3], components[index*3+1], components[index*3+2], 0xFF}
+	}
+	return palette, nil
+}
+
+// String formats the palette in the hex format that TIC-80 uses to import and export palettes.
+func (palette Palette) String() string {
+	components := make([]byte, 0, len(palette)*3)
+	for _, entry := range palette {
+		components = append(components, entry.R, entry.G, entry.B)
+	}
+	return hex.EncodeToString(components)
+}
+
+// Lerp returns the palette linearly interpolated between this palette and the target palette.
+// An amount of 0 returns this palette, and an amount of 1 returns the target palette.
+func (palette Palette) Lerp(target Palette, amount float32) Palette {
+	for index := range palette {
+		palette[index] = lerpColor(palette[index], target[index], amount)
+	}
+	return palette
+}
+
+// Fade returns the palette with every color linearly interpolated toward the target color,
+// such as black to fade out or white to flash.
+// An amount of 0 returns this palette, and an amount of 1 returns a palette of only the target color.
+func (palette Palette) Fade(target color.RGBA, amount float32) Palette {
+	for index := range palette {
+		palette[index] = lerpColor(palette[index], target, amount)
+	}
+	return palette
+}
+
+// lerpColor linearly interpolates between two colors.
+func lerpColor(from, to color.RGBA, amount float32) color.RGBA {
+	if amount <= 0 {
+		return from
+	} else if amount >= 1 {
+		return to
+	}
+	return color.RGBA{
+		R: lerpComponent(from.R, to.R, amount),
+		G: lerpComponent(from.G, to.G, amount),
+		B: lerpComponent(from.B, to.B, amount),
+		A: 0xFF,
+	}
+}
+
+// lerpComponent linearly interpolates between two color components.
+func lerpComponent(from, to byte, amount float32) byte {
+	return byte(float32(from) + (float32(to)-float32(from))*amount + 0.5)
+}
+
+// RGBA returns the specified color.
+func (palette *PaletteMemory) RGBA(index int) color.RGBA {
+	red, green, blue := palette.Color(index)
+	return color.RGBA{red, green, blue, 0xFF}
+}
+
+// SetRGBA sets the specified color, ignoring its alpha.
+func (palette *PaletteMemory) SetRGBA(index int, value color.RGBA) {
+	palette.SetColor(index, value.R, value.G, value.B)
+}
+
+// Palette returns a copy of all 16 colors.
+func (palette *PaletteMemory) Palette() Palette {
+	var copied Palette
+	for index := range copied {
+		copied[index] = palette.RGBA(index)
+	}
+	return copied
+}
+
+// SetPalette sets all 16 colors.
+func (palette *PaletteMemory) SetPalette(value Palette) {
+	for index, entry := range value {
+		palette.SetRGBA(index, entry)
+	}
+}
+
+// LoadPalette returns a copy of the palette of the specified video bank.
+func LoadPalette(bank int) Palette {
+	previousBank := Vbank(bank)
+	palette := PALETTE.Palette()
+	Vbank(previousBank)
+	return palette
+}
+
+// StorePalette sets the palette of the specified video bank.
+func StorePalette(bank int, palette Palette) {
+	previousBank := Vbank(bank)
+	PALETTE.SetPalette(palette)
+	Vbank(previousBank)
+}
+
+// Pal remaps a color, so that everything drawn with the from color is drawn with the to color instead.
+// This applies to every drawing function, including [tic80.Spr], [tic80.Map], [tic80.Print] and [tic80.Font].
+func Pal(from, to int) {
+	PALETTE_MAP.SetColor(from, to)
+}
+
+// PalReset removes all color remapping.
+func PalReset() {
+	for index := 0; index < 16; index++ {
+		PALETTE_MAP.SetColor(index, index)
+	}
+}
+
+// WithPaletteMap calls draw, restoring the palette map to what it was beforehand once it returns.
+// Colors remapped with [tic80.Pal] within draw apply only to draw.
+func WithPaletteMap(draw func()) {
+	previousPaletteMap := *PALETTE_MAP
+	defer func() {
+		*PALETTE_MAP = previousPaletteMap
+	}()
+	draw()
+}
@@ -0,0 +1,214 @@
+package tic80
+
+// PointerEvent is an enumeration of the events of a mouse button, as told by [tic80.Pointer].
+type PointerEvent int
+
+// Pointer Events
+const (
+	POINTER_PRESS PointerEvent = iota
+	POINTER_RELEASE
+	POINTER_CLICK
+	POINTER_DOUBLE_CLICK
+	POINTER_LONG_PRESS
+	POINTER_DRAG_START
+	POINTER_DRAG_MOVE
+	POINTER_DRAG_END
+)
+
+// Pointer Defaults
+const (
+	POINTER_DOUBLE_CLICK_TIME = 300
+	POINTER_LONG_PRESS_TIME   = 500
+	POINTER_DRAG_DISTANCE     = 3
+)
+
+// pointerButton is the state of one mouse button of [tic80.Pointer].
+type pointerButton struct {
+	pressed     bool
+	pressTime   float32
+	pressX      int
+	pressY      int
+	longPressed bool
+	dragging    bool
+	clicked     bool
+	clickTime   float32
+	clickX      int
+	clickY      int
+	events      uint8
+}
+
+// Pointer turns the successive states of the mouse into events, such as clicks and drags, for each mouse button.
+// Timing is measured with [tic80.Time], so it does not depend on the frame rate.
+//
+// [tic80.Pointer.Update] must be called once every frame, at the start of [tic80.Game.Tic].
+type Pointer struct {
+	x               int
+	y               int
+	previousX       int
+	previousY       int
+	scrollX         int
+	scrollY         int
+	buttons         [3]pointerButton
+	doubleClickTime float32
+	longPressTime   float32
+	dragDistance    int
+	updated         bool
+}
+
+// NewPointer constructs a [tic80.Pointer] object with double clicks within [tic80.POINTER_DOUBLE_CLICK_TIME] milliseconds,
+// long presses after [tic80.POINTER_LONG_PRESS_TIME] milliseconds, and drags after moving [tic80.POINTER_DRAG_DISTANCE] pixels.
+func NewPointer() *Pointer {
+	return &Pointer{
+		doubleClickTime: POINTER_DOUBLE_CLICK_TIME,
+		longPressTime:   POINTER_LONG_PRESS_TIME,
+		dragDistance:    POINTER_DRAG_DISTANCE,
+	}
+}
+
+// SetDoubleClickTime sets the most milliseconds between two clicks for the second to be a double click.
+func (pointer *Pointer) SetDoubleClickTime(milliseconds float32) *Pointer {
+	pointer.doubleClickTime = milliseconds
+	return pointer
+}
+
+// SetLongPressTime sets the milliseconds a button must be held without dragging for a long press.
+func (pointer *Pointer) SetLongPressTime(milliseconds float32) *Pointer {
+	pointer.longPressTime = milliseconds
+	return pointer
+}
+
+// SetDragDistance sets the pixels the mouse must move while a button is held for a drag to start.
+func (pointer *Pointer) SetDragDistance(pixels int) *Pointer {
+	pointer.dragDistance = pixels
+	return pointer
+}
+
+// within returns true if two positions are less than the drag distance apart in both directions; false otherwise.
+func (pointer *Pointer) within(x0, y0, x1, y1 int) bool {
+	return absolute(x1-x0) < pointer.dragDistance && absolute(y1-y0) < pointer.dragDistance
+}
+
+// absolute returns the absolute value of a number.
+func absolute(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// maximum returns the greater of two numbers.
+func maximum(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// minimum returns the lesser of two numbers.
+func minimum(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Update reads the mouse for this frame, and works out the events of each button.
+func (pointer *Pointer) Update() {
+	x, y, left, middle, right, scrollX, scrollY := Mouse()
+	now := Time()
+	if !pointer.updated {
+		pointer.x, pointer.y = x, y
+		pointer.updated = true
+	}
+	pointer.previousX, pointer.previousY = pointer.x, pointer.y
+	pointer.x, pointer.y = x, y
+	pointer.scrollX += scrollX
+	pointer.scrollY += scrollY
+
+	for index, pressed := range [3]bool{left, middle, right} {
+		button := &pointer.buttons[index]
+		button.events = 0
+		if !button.pressed {
+			button.dragging = false
+		}
+		event := func(event PointerEvent) {
+			button.events |= 1 << event
+		}
+
+		switch {
+		case pressed && !button.pressed:
+			event(POINTER_PRESS)
+			button.pressTime, button.pressX, button.pressY = now, x, y
+			button.longPressed, button.dragging = false, false
+		case pressed:
+			if !button.dragging && !pointer.within(button.pressX, button.pressY, x, y) {
+				event(POINTER_DRAG_START)
+				button.dragging = true
+			} else if button.dragging && (x != pointer.previousX || y != pointer.previousY) {
+				event(POINTER_DRAG_MOVE)
+			}
+			if !button.dragging && !button.longPressed && now-button.pressTime >= pointer.longPressTime {
+				event(POINTER_LONG_PRESS)
+				button.longPressed = true
+			}
+		case button.pressed:
+			event(POINTER_RELEASE)
+			if button.dragging {
+				event(POINTER_DRAG_END)
+				break
+			}
+			if button.longPressed {
+				break
+			}
+			event(POINTER_CLICK)
+			if button.clicked && now-button.clickTime <= pointer.doubleClickTime && pointer.within(button.clickX, button.clickY, x, y) {
+				event(POINTER_DOUBLE_CLICK)
+				button.clicked = false
+			} else {
+				button.clicked, button.clickTime, button.clickX, button.clickY = true, now, x, y
+			}
+		}
+		button.pressed = pressed
+	}
+}
+
+// Position returns the position of the mouse.
+func (pointer *Pointer) Position() (x, y int) {
+	return pointer.x, pointer.y
+}
+
+// Delta returns how far the mouse moved this frame.
+func (pointer *Pointer) Delta() (deltaX, deltaY int) {
+	return pointer.x - pointer.previousX, pointer.y - pointer.previousY
+}
+
+// Pressed returns true if a mouse button is pressed; false otherwise.
+func (pointer *Pointer) Pressed(button MouseButton) bool {
+	return pointer.buttons[button%3].pressed
+}
+
+// Event returns true if an event of a mouse button happened this frame; false otherwise.
+// A click is a press and release that is neither a drag nor a long press, and a double click is also a click.
+func (pointer *Pointer) Event(button MouseButton, event PointerEvent) bool {
+	return pointer.buttons[button%3].events&(1<<event) != 0
+}
+
+// DragDelta returns how far the mouse has moved since a mouse button was pressed, or 0 if the button is not dragging.
+// On the frame of [tic80.POINTER_DRAG_END], it returns how far the drag went.
+func (pointer *Pointer) DragDelta(button MouseButton) (deltaX, deltaY int) {
+	state := &pointer.buttons[button%3]
+	if !state.dragging {
+		return 0, 0
+	}
+	return pointer.x - state.pressX, pointer.y - state.pressY
+}
+
+// Scroll returns the scroll of the mouse wheel accumulated since the pointer was constructed or the scroll was reset.
+func (pointer *Pointer) Scroll() (scrollX, scrollY int) {
+	return pointer.scrollX, pointer.scrollY
+}
+
+// ResetScroll resets the accumulated scroll of the mouse wheel, such as after scrolling a list by it.
+func (pointer *Pointer) ResetScroll() {
+	pointer.scrollX, pointer.scrollY = 0, 0
+}
@@ -0,0 +1,125 @@
+package tic80
+
+import "image/color"
+
+// Scanline Dimensions
+const (
+	SCANLINE_BORDER = 4
+	SCANLINE_COUNT  = SCREEN_HEIGHT + 2*SCANLINE_BORDER
+)
+
+// scanlineColor is a single palette entry to change before a scanline.
+type scanlineColor struct {
+	index int
+	red   byte
+	green byte
+	blue  byte
+}
+
+// scanlineEffect is the set of changes to make before a scanline.
+type scanlineEffect struct {
+	palette   *PaletteMemory
+	colors    []scanlineColor
+	hasOffset bool
+	offset    ScreenOffsetMemory
+}
+
+// RasterEffects changes the palette and screen offset between scanlines, as they are drawn.
+// Scanlines are numbered as they are passed to [tic80.Game.Bdr], from 0 to [tic80.SCANLINE_COUNT]-1,
+// where the screen starts at [tic80.SCANLINE_BORDER].
+// Each change stays in effect until it is changed again, including into the next frame.
+type RasterEffects struct {
+	scanlines [SCANLINE_COUNT]scanlineEffect
+	callback  func(scanline int)
+}
+
+var currentRasterEffects *RasterEffects
+
+// NewRasterEffects constructs a [tic80.RasterEffects] object with no changes.
+func NewRasterEffects() *RasterEffects {
+	return new(RasterEffects)
+}
+
+// SetRasterEffects sets the raster effects to apply while the screen is drawn, or nil to apply none.
+// They are applied before [tic80.Game.Bdr] is called for each scanline.
+func SetRasterEffects(effects *RasterEffects) {
+	currentRasterEffects = effects
+}
+
+// SetPalette sets the whole palette before the specified scanline.
+func (effects *RasterEffects) SetPalette(scanline int, palette Palette) *RasterEffects {
+	if scanline >= 0 && scanline < SCANLINE_COUNT {
+		effect := &effects.scanlines[scanline]
+		if effect.palette == nil {
+			effect.palette = new(PaletteMemory)
+		}
+		effect.palette.SetPalette(palette)
+	}
+	return effects
+}
+
+// SetColor sets one color of the palette before the specified scanline, replacing the color set for that index before.
+func (effects *RasterEffects) SetColor(scanline, index int, value color.RGBA) *RasterEffects {
+	if scanline >= 0 && scanline < SCANLINE_COUNT {
+		effect := &effects.scanlines[scanline]
+		change := scanlineColor{index % 16, value.R, value.G, value.B}
+		for existing := range effect.colors {
+			if effect.colors[existing].index == change.index {
+				effect.colors[existing] = change
+				return effects
+			}
+		}
+		effect.colors = append(effect.colors, change)
+	}
+	return effects
+}
+
+// SetOffset sets the screen offset before the specified scanline.
+func (effects *RasterEffects) SetOffset(scanline, x, y int) *RasterEffects {
+	if scanline >= 0 && scanline < SCANLINE_COUNT {
+		effect := &effects.scanlines[scanline]
+		effect.hasOffset = true
+		effect.offset.SetOffset(x, y)
+	}
+	return effects
+}
+
+// SetCallback sets a function to call before each scanline, after the tables have been applied.
+func (effects *RasterEffects) SetCallback(callback func(scanline int)) *RasterEffects {
+	effects.callback = callback
+	return effects
+}
+
+// Clear removes all changes and the callback.
+func (effects *RasterEffects) Clear() *RasterEffects {
+	*effects = RasterEffects{}
+	return effects
+}
+
+// ClearScanline removes all changes before the specified scanline.
+func (effects *RasterEffects) ClearScanline(scanline int) *RasterEffects {
+	if scanline >= 0 && scanline < SCANLINE_COUNT {
+		effect := &effects.scanlines[scanline]
+		*effect = scanlineEffect{colors: effect.colors[:0]}
+	}
+	return effects
+}
+
+// apply makes the changes for the specified scanline.
+func (effects *RasterEffects) apply(scanline int) {
+	if scanline >= 0 && scanline < SCANLINE_COUNT {
+		effect := &effects.scanlines[scanline]
+		if effect.palette != nil {
+			*PALETTE = *effect.palette
+		}
+		for _, change := range effect.colors {
+			PALETTE.SetColor(change.index, change.red, change.green, change.blue)
+		}
+		if effect.hasOffset {
+			*SCREEN_OFFSET = effect.offset
+		}
+	}
+	if effects.callback != nil {
+		effects.callback(scanline)
+	}
+}
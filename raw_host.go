@@ -0,0 +1,317 @@
+//go:build !tinygo
+
+package tic80
+
+import (
+	"io"
+	"os"
+	"time"
+	"unsafe"
+)
+
+// Memory Areas
+var (
+	IO_RAM   = new([0x18000]byte)
+	FREE_RAM = new([0x28000]byte)
+)
+
+// HOST_TRACE is where [tic80.Trace] writes to when running on the host.
+var HOST_TRACE io.Writer = os.Stdout
+
+// hostState is the state of the host that TIC-80 keeps outside of RAM.
+type hostState struct {
+	frame            int
+	exited           bool
+	clipLeft         int
+	clipTop          int
+	clipRight        int
+	clipBottom       int
+	videoBank        int
+	videoBanks       [2][ADDRESS_TILES]byte
+	previousGamepads GamepadMemory
+	gamepadHolds     [32]int
+	previousKeyboard KeyboardMemory
+	keyboardHolds    [KEY_ALT + 1]int
+}
+
+var host hostState
+
+func init() {
+	HostReset()
+}
+
+// HostReset restores the host to the state TIC-80 is in when a cartridge starts,
+// with the default palette and fonts, and all other memory cleared.
+func HostReset() {
+	*IO_RAM = [len(IO_RAM)]byte{}
+	*FREE_RAM = [len(FREE_RAM)]byte{}
+	host = hostState{}
+	resetSound()
+	started = false
+	currentRasterEffects = nil
+	currentMusicEvents = nil
+	currentInputPlayer = nil
+
+	palette, _ := ParsePalette(SWEETIE_16)
+	for bank := range host.videoBanks {
+		Vbank(bank)
+		PALETTE.SetPalette(palette)
+		PalReset()
+	}
+	Vbank(0)
+
+	for character, glyph := range hostFont {
+		for y, row := range glyph {
+			for x, pixel := range row {
+				FONT.SetPixel(byte(character), x, y, pixel == '#')
+			}
+		}
+	}
+	for character, glyph := range hostAlternateFont {
+		for y, row := range glyph {
+			for x, pixel := range row {
+				FONT.SetPixel(byte(character)+ALTERNATE_FONT_OFFSET, x, y, pixel == '#')
+			}
+		}
+	}
+
+	MUSIC_STATE[0], MUSIC_STATE[1], MUSIC_STATE[2] = 0xFF, 0xFF, 0xFF
+	rawClip(0, 0, SCREEN_WIDTH, SCREEN_HEIGHT)
+}
+
+// HostAdvance ends the current frame on the host, as TIC-80 does after each call to TIC.
+// The input in [tic80.GAMEPADS] and [tic80.KEYBOARD] becomes the previous input for [tic80.Btnp] and [tic80.Keyp],
+// The sound registers are synthesized into [tic80.HostAudio], then the music and sound effects play their next tick into them,
+// and [tic80.Time] advances by one frame.
+func HostAdvance() {
+	for id := range host.gamepadHolds {
+		if GAMEPADS.Pressed(ButtonCode(id)) {
+			host.gamepadHolds[id]++
+		} else {
+			host.gamepadHolds[id] = 0
+		}
+	}
+	host.previousGamepads = *GAMEPADS
+
+	for id := range host.keyboardHolds {
+		if KEYBOARD.Pressed(KeyCode(id)) {
+			host.keyboardHolds[id]++
+		} else {
+			host.keyboardHolds[id] = 0
+		}
+	}
+	host.previousKeyboard = *KEYBOARD
+
+	advanceAudio()
+	host.frame++
+}
+
+// HostTick runs one frame of the game registered with [tic80.Run], as TIC-80 does:
+// it calls TIC, then OVR in [tic80.LAYER_OVERLAY], then BDR for each scanline, and finally [tic80.HostAdvance].
+// The game is booted first, if it has not been already.
+func HostTick() {
+	exportTic()
+	LAYER_OVERLAY.Draw(exportOvr)
+	for scanline := 0; scanline < SCANLINE_COUNT; scanline++ {
+		exportBdr(int32(scanline))
+	}
+	HostAdvance()
+}
+
+// HostFrame returns the number of frames that have been advanced with [tic80.HostAdvance] since the host was reset.
+func HostFrame() int {
+	return host.frame
+}
+
+// HostExited returns true if [tic80.Exit] was called since the host was reset; false otherwise.
+func HostExited() bool {
+	return host.exited
+}
+
+// repeated returns true if an input that has been held for the given number of frames repeats this frame.
+func repeated(holds, hold, period int) bool {
+	return hold >= 0 && period > 0 && holds >= hold && (holds-hold)%period == 0
+}
+
+func rawBtn(id int32) int32 {
+	if GAMEPADS.Pressed(ButtonCode(id)) {
+		return 1
+	}
+	return 0
+}
+
+func rawBtnp(id, hold, period int32) bool {
+	if !GAMEPADS.Pressed(ButtonCode(id)) {
+		return false
+	}
+	return !host.previousGamepads.Pressed(ButtonCode(id)) || repeated(host.gamepadHolds[id%32], int(hold), int(period))
+}
+
+func rawKey(id int32) int32 {
+	if id == 0 {
+		for _, key := range KEYBOARD {
+			if key != 0 {
+				return 1
+			}
+		}
+		return 0
+	}
+	if KEYBOARD.Pressed(KeyCode(id)) {
+		return 1
+	}
+	return 0
+}
+
+func rawKeyp(id int8, hold, period int32) int32 {
+	if id <= 0 || int(id) >= len(host.keyboardHolds) || !KEYBOARD.Pressed(KeyCode(id)) {
+		return 0
+	}
+	if !host.previousKeyboard.Pressed(KeyCode(id)) || repeated(host.keyboardHolds[id], int(hold), int(period)) {
+		return 1
+	}
+	return 0
+}
+
+func rawMouse(data *mouseData) {
+	x, y := MOUSE.Position()
+	data.x = int16(x)
+	data.y = int16(y)
+	data.left, data.middle, data.right = MOUSE.Buttons()
+	scrollX, scrollY := MOUSE.Scroll()
+	data.scrollX = int8(scrollX)
+	data.scrollY = int8(scrollY)
+}
+
+// hostMemory returns the byte at the given address of [tic80.IO_RAM] followed by [tic80.FREE_RAM], or nil if there is none.
+func hostMemory(address int) *byte {
+	switch {
+	case address >= 0 && address < len(IO_RAM):
+		return &IO_RAM[address]
+	case address >= len(IO_RAM) && address < len(IO_RAM)+len(FREE_RAM):
+		return &FREE_RAM[address-len(IO_RAM)]
+	}
+	return nil
+}
+
+func rawPeek(address int32, bits int8) int8 {
+	if bits != 1 && bits != 2 && bits != 4 && bits != 8 {
+		return 0
+	}
+	perByte := 8 / int(bits)
+	value := hostMemory(int(address) / perByte)
+	if value == nil {
+		return 0
+	}
+	shift := int(address) % perByte * int(bits)
+	return int8(*value >> shift & byte(1<<bits-1))
+}
+
+func rawPoke(address int32, value, bits int8) {
+	if bits != 1 && bits != 2 && bits != 4 && bits != 8 {
+		return
+	}
+	perByte := 8 / int(bits)
+	target := hostMemory(int(address) / perByte)
+	if target == nil {
+		return
+	}
+	shift := int(address) % perByte * int(bits)
+	mask := byte(1<<bits-1) << shift
+	*target = *target&^mask | byte(value)<<shift&mask
+}
+
+func rawMemcpy(destination, source, length int32) {
+	buffer := make([]byte, 0, length)
+	for offset := int32(0); offset < length; offset++ {
+		if value := hostMemory(int(source + offset)); value != nil {
+			buffer = append(buffer, *value)
+		} else {
+			buffer = append(buffer, 0)
+		}
+	}
+	for offset, value := range buffer {
+		if target := hostMemory(int(destination) + offset); target != nil {
+			*target = value
+		}
+	}
+}
+
+func rawMemset(address, value, length int32) {
+	for offset := int32(0); offset < length; offset++ {
+		if target := hostMemory(int(address + offset)); target != nil {
+			*target = byte(value)
+		}
+	}
+}
+
+func rawPmem(address int32, value int64) uint32 {
+	previous := PERSISTENT_MEMORY.Value(int(address))
+	if value >= 0 {
+		PERSISTENT_MEMORY.SetValue(int(address), uint32(value))
+	}
+	return previous
+}
+
+func rawFget(sprite int32, flag int8) bool {
+	return SPRITE_FLAGS.Flag(int(sprite), int(flag))
+}
+
+func rawFset(sprite int32, flag int8, value bool) {
+	SPRITE_FLAGS.SetFlag(int(sprite), int(flag), value)
+}
+
+func rawMget(x, y int32) int32 {
+	return int32(MAP.Tile(wrap(int(x), MAP_WIDTH), wrap(int(y), MAP_HEIGHT)))
+}
+
+func rawMset(x, y, value int32) {
+	MAP.SetTile(wrap(int(x), MAP_WIDTH), wrap(int(y), MAP_HEIGHT), int(value))
+}
+
+func rawSync(mask int32, bank, toCart int8) {}
+
+func rawExit() {
+	host.exited = true
+}
+
+func rawTime() float32 {
+	return float32(host.frame) * 1000 / 60
+}
+
+func rawTstamp() uint32 {
+	return uint32(time.Now().Unix())
+}
+
+func rawTrace(messageBuffer unsafe.Pointer, color int8) {
+	io.WriteString(HOST_TRACE, fromTextData(messageBuffer)+"\n")
+}
+
+// fromTextData transforms text prepared by toTextData back into a Go string.
+func fromTextData(textBuffer unsafe.Pointer) string {
+	length := 0
+	for *(*byte)(unsafe.Add(textBuffer, length)) != 0 {
+		length++
+	}
+	return string(unsafe.Slice((*byte)(textBuffer), length))
+}
+
+// fromByteData transforms a buffer prepared by toByteData back into a Go slice of bytes.
+func fromByteData(buffer unsafe.Pointer, count int8) []byte {
+	if buffer == nil || count <= 0 {
+		return nil
+	}
+	return unsafe.Slice((*byte)(buffer), count)
+}
+
+func rawVbank(bank int8) int8 {
+	previous := int8(host.videoBank)
+	if bank >= 0 && int(bank) < len(host.videoBanks) && int(bank) != host.videoBank {
+		copy(host.videoBanks[host.videoBank][:], IO_RAM[:ADDRESS_TILES])
+		copy(IO_RAM[:ADDRESS_TILES], host.videoBanks[bank][:])
+		host.videoBank = int(bank)
+	}
+	return previous
+}
+
+// Start does nothing on the host, where there is no runtime to start.
+func Start() {}
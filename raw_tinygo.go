@@ -0,0 +1,134 @@
+//go:build tinygo
+
+package tic80
+
+import "unsafe"
+
+// Memory Areas
+var (
+	IO_RAM   = (*[0x18000]byte)(unsafe.Pointer(uintptr(0x00000)))
+	FREE_RAM = (*[0x28000]byte)(unsafe.Pointer(uintptr(0x18000)))
+)
+
+//go:export btn
+func rawBtn(id int32) int32
+
+//go:export btnp
+func rawBtnp(id, hold, period int32) bool
+
+//go:export clip
+func rawClip(x, y, width, height int32)
+
+//go:export cls
+func rawCls(color int8)
+
+//go:export circ
+func rawCirc(x, y, radius int32, color int8)
+
+//go:export circb
+func rawCircb(x, y, radius int32, color int8)
+
+//go:export elli
+func rawElli(x, y, radiusX, radiusY int32, color int8)
+
+//go:export ellib
+func rawEllib(x, y, radiusX, radiusY int32, color int8)
+
+//go:export exit
+func rawExit()
+
+//go:export fget
+func rawFget(sprite int32, flag int8) bool
+
+//go:export fset
+func rawFset(sprite int32, flag int8, value bool)
+
+//go:export font
+func rawFont(textBuffer unsafe.Pointer, x, y int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, characterWidth, characterHeight int8, fixed bool, scale int8, useAlternateFontPage bool) int32
+
+//go:export key
+func rawKey(id int32) int32
+
+//go:export keyp
+func rawKeyp(id int8, hold, period int32) int32
+
+//go:export line
+func rawLine(x0, y0, x1, y1 float32, color int8)
+
+//go:export map
+func rawMap(x, y, width, height, screenX, screenY int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, unused int32)
+
+//go:export memcpy
+func rawMemcpy(destination, source, length int32)
+
+//go:export memset
+func rawMemset(address, value, length int32)
+
+//go:export mget
+func rawMget(x, y int32) int32
+
+//go:export mset
+func rawMset(x, y, value int32)
+
+//go:export mouse
+func rawMouse(data *mouseData)
+
+//go:export music
+func rawMusic(track, frame, row int32, loop, sustain bool, tempo, speed int32)
+
+//go:export peek
+func rawPeek(address int32, bits int8) int8
+
+//go:export pix
+func rawPix(x, y int32, color int8) uint8
+
+//go:export pmem
+func rawPmem(address int32, value int64) uint32
+
+//go:export poke
+func rawPoke(address int32, value, bits int8)
+
+//go:export print
+func rawPrint(textBuffer unsafe.Pointer, x, y int32, color, fixed, scale, useAlternateFontPage int8) int32
+
+//go:export rect
+func rawRect(x, y, width, height int32, color int8)
+
+//go:export rectb
+func rawRectb(x, y, width, height int32, color int8)
+
+//go:export sfx
+func rawSfx(id, note, octave, duration, channel, volumeLeft, volumeRight, speed int32)
+
+//go:export spr
+func rawSpr(id, x, y int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, scale, flip, rotate, width, height int32)
+
+//go:export sync
+func rawSync(mask int32, bank, toCart int8)
+
+//go:export ttri
+func rawTtri(x0, y0, x1, y1, x2, y2, u0, v0, u1, v1, u2, v2 float32, useTiles int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, z0, z1, z2 float32, depth bool)
+
+//go:export time
+func rawTime() float32
+
+//go:export trace
+func rawTrace(messageBuffer unsafe.Pointer, color int8)
+
+//go:export tri
+func rawTri(x0, y0, x1, y1, x2, y2 float32, color int8)
+
+//go:export trib
+func rawTrib(x0, y0, x1, y1, x2, y2 float32, color int8)
+
+//go:export tstamp
+func rawTstamp() uint32
+
+//go:export vbank
+func rawVbank(bank int8) int8
+
+// Start is a workaround to allow TIC-80 to run Go code.
+// This should be the first function run in BOOT, unless the game is run with [tic80.Run].
+//
+//go:linkname Start _start
+func Start()
@@ -0,0 +1,289 @@
+package tic80
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// Input Recording Dimensions
+const (
+	INPUT_STATE_SIZE  = ADDRESS_KEYBOARD + 4 - ADDRESS_GAMEPADS
+	inputVersion      = 1
+	inputTracePrefix  = "input:"
+	inputTraceLength  = 64
+	inputPmemCapacity = 256
+)
+
+// inputRun is a run of frames with the same input.
+type inputRun struct {
+	frames int
+	state  [INPUT_STATE_SIZE]byte
+}
+
+// InputRecording is the input of the gamepads, mouse and keyboard for each frame, as seen through
+// [tic80.Btn], [tic80.Key] and [tic80.Mouse], stored as runs of frames with the same input.
+type InputRecording struct {
+	runs []inputRun
+}
+
+// NewInputRecording constructs an empty [tic80.InputRecording] object.
+func NewInputRecording() *InputRecording {
+	return new(InputRecording)
+}
+
+// Frames returns the number of frames recorded.
+func (recording *InputRecording) Frames() int {
+	frames := 0
+	for _, run := range recording.runs {
+		frames += run.frames
+	}
+	return frames
+}
+
+// inputState returns the input of the current frame.
+func inputState() (state [INPUT_STATE_SIZE]byte) {
+	copy(state[:], IO_RAM[ADDRESS_GAMEPADS:])
+	return
+}
+
+// append adds a frame of input to the end of the recording.
+func (recording *InputRecording) append(state [INPUT_STATE_SIZE]byte) {
+	if last := len(recording.runs) - 1; last >= 0 && recording.runs[last].state == state {
+		recording.runs[last].frames++
+		return
+	}
+	recording.runs = append(recording.runs, inputRun{1, state})
+}
+
+// Bytes encodes the recording compactly.
+// Each run stores its number of frames and only the bytes of input that changed since the previous run.
+func (recording *InputRecording) Bytes() []byte {
+	data := []byte{inputVersion}
+	var previous [INPUT_STATE_SIZE]byte
+	for _, run := range recording.runs {
+		for frames := run.frames; ; frames >>= 7 {
+			if frames < 0x80 {
+				data = append(data, byte(frames))
+				break
+			}
+			data = append(data, byte(frames)|0x80)
+		}
+		var changed uint16
+		for index := range run.state {
+			if run.state[index] != previous[index] {
+				changed |= 1 << index
+			}
+		}
+		data = append(data, byte(changed), byte(changed>>8))
+		for index := range run.state {
+			if changed&(1<<index) != 0 {
+				data = append(data, run.state[index])
+			}
+		}
+		previous = run.state
+	}
+	return append(data, 0)
+}
+
+// ParseInputRecording decodes a recording encoded with [tic80.InputRecording.Bytes].
+// Anything after the end of the recording is ignored.
+func ParseInputRecording(data []byte) (*InputRecording, error) {
+	if len(data) == 0 || data[0] != inputVersion {
+		return nil, errors.New("tic80: not an input recording")
+	}
+	recording := new(InputRecording)
+	var state [INPUT_STATE_SIZE]byte
+	for offset := 1; ; {
+		frames := 0
+		for shift := 0; ; shift += 7 {
+			if offset >= len(data) || shift > 28 {
+				return nil, errors.New("tic80: truncated input recording")
+			}
+			next := data[offset]
+			offset++
+			// The number of frames must fit in 31 bits, since int is 32 bits on TIC-80.
+			if shift == 28 && next&0x7F > 0x07 {
+				return nil, errors.New("tic80: input recording has too many frames in a run")
+			}
+			frames |= int(next&0x7F) << shift
+			if next&0x80 == 0 {
+				break
+			}
+		}
+		if frames == 0 {
+			return recording, nil
+		}
+
+		if len(data)-offset < 2 {
+			return nil, errors.New("tic80: truncated input recording")
+		}
+		changed := uint16(data[offset]) | uint16(data[offset+1])<<8
+		offset += 2
+		for index := range state {
+			if changed&(1<<index) == 0 {
+				continue
+			}
+			if offset >= len(data) {
+				return nil, errors.New("tic80: truncated input recording")
+			}
+			state[index] = data[offset]
+			offset++
+		}
+		recording.runs = append(recording.runs, inputRun{frames, state})
+	}
+}
+
+// SavePmem saves the recording with [tic80.Pmem], starting at the specified address, as its length in bytes followed by its bytes.
+// It returns the address after the last value written, or an error if the recording does not fit in persistent memory.
+func (recording *InputRecording) SavePmem(address int) (int, error) {
+	data := recording.Bytes()
+	values := (len(data) + 3) / 4
+	if address < 0 || address+1+values > inputPmemCapacity {
+		return address, errors.New("tic80: input recording does not fit in persistent memory")
+	}
+	Pmem(address, int64(len(data)))
+	address++
+	for index := 0; index < len(data); index += 4 {
+		var value uint32
+		for offset := 0; offset < 4 && index+offset < len(data); offset++ {
+			value |= uint32(data[index+offset]) << (offset * 8)
+		}
+		Pmem(address, int64(value))
+		address++
+	}
+	return address, nil
+}
+
+// LoadInputRecordingPmem loads a recording saved with [tic80.InputRecording.SavePmem] at the specified address.
+func LoadInputRecordingPmem(address int) (*InputRecording, error) {
+	if address < 0 || address >= inputPmemCapacity {
+		return nil, errors.New("tic80: address is outside of persistent memory")
+	}
+	// The length is checked before it is converted, since int is 32 bits on TIC-80.
+	stored := Pmem(address, -1)
+	if stored > uint32(inputPmemCapacity-address-1)*4 {
+		return nil, errors.New("tic80: not an input recording")
+	}
+	length := int(stored)
+	data := make([]byte, 0, length)
+	for index := 0; index < length; index++ {
+		value := Pmem(address+1+index/4, -1)
+		data = append(data, byte(value>>(index%4*8)))
+	}
+	return ParseInputRecording(data)
+}
+
+// SaveFreeRAM saves the recording in [tic80.FREE_RAM], starting at the specified offset, as its length in bytes,
+// in four bytes, followed by its bytes.
+// Since the Go heap starts at the beginning of [tic80.FREE_RAM] on TIC-80, the offset must be in a part of it that the game reserves.
+// It returns the offset after the last byte written, or an error if the recording does not fit in free memory.
+func (recording *InputRecording) SaveFreeRAM(offset int) (int, error) {
+	data := recording.Bytes()
+	if offset < 0 || offset+4+len(data) > len(FREE_RAM) {
+		return offset, errors.New("tic80: input recording does not fit in free memory")
+	}
+	length := uint32(len(data))
+	FREE_RAM[offset] = byte(length)
+	FREE_RAM[offset+1] = byte(length >> 8)
+	FREE_RAM[offset+2] = byte(length >> 16)
+	FREE_RAM[offset+3] = byte(length >> 24)
+	offset += 4
+	return offset + copy(FREE_RAM[offset:], data), nil
+}
+
+// LoadInputRecordingFreeRAM loads a recording saved with [tic80.InputRecording.SaveFreeRAM] at the specified offset.
+func LoadInputRecordingFreeRAM(offset int) (*InputRecording, error) {
+	if offset < 0 || offset+4 > len(FREE_RAM) {
+		return nil, errors.New("tic80: offset is outside of free memory")
+	}
+	// The length is checked before it is converted, since int is 32 bits on TIC-80.
+	stored := uint32(FREE_RAM[offset]) | uint32(FREE_RAM[offset+1])<<8 | uint32(FREE_RAM[offset+2])<<16 | uint32(FREE_RAM[offset+3])<<24
+	offset += 4
+	if stored > uint32(len(FREE_RAM)-offset) {
+		return nil, errors.New("tic80: not an input recording")
+	}
+	return ParseInputRecording(FREE_RAM[offset : offset+int(stored)])
+}
+
+// Trace writes the recording with [tic80.Trace] as lines of hexadecimal digits, so that it can be copied from the console,
+// such as to attach it to a bug report. [tic80.ParseInputTrace] reads it back.
+func (recording *InputRecording) Trace() {
+	text := hex.EncodeToString(recording.Bytes())
+	for start := 0; start < len(text); start += inputTraceLength {
+		end := start + inputTraceLength
+		if end > len(text) {
+			end = len(text)
+		}
+		Trace(inputTracePrefix+text[start:end], nil)
+	}
+}
+
+// ParseInputTrace decodes a recording written with [tic80.InputRecording.Trace] from the text of the console.
+// Lines that were not written by it are ignored.
+func ParseInputTrace(text string) (*InputRecording, error) {
+	var digits strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if _, after, found := strings.Cut(line, inputTracePrefix); found {
+			digits.WriteString(strings.TrimSpace(after))
+		}
+	}
+	data, err := hex.DecodeString(digits.String())
+	if err != nil {
+		return nil, errors.New("tic80: input trace contains an invalid hexadecimal digit")
+	}
+	return ParseInputRecording(data)
+}
+
+// inputPlayer is the recording or replaying of input while the game runs.
+type inputPlayer struct {
+	recording *InputRecording
+	replaying bool
+	run       int
+	frame     int
+}
+
+var currentInputPlayer *inputPlayer
+
+// StartRecording starts recording the input of each frame to the end of a recording,
+// just before [tic80.Game.Tic] is called.
+func StartRecording(recording *InputRecording) {
+	currentInputPlayer = &inputPlayer{recording: recording}
+}
+
+// StartReplay starts replaying a recording from its first frame.
+// Until it ends, the input of each frame is replaced just before [tic80.Game.Tic] is called,
+// so [tic80.Btn], [tic80.Key], [tic80.Mouse] and the input views return the recorded input.
+func StartReplay(recording *InputRecording) {
+	currentInputPlayer = &inputPlayer{recording: recording, replaying: true}
+}
+
+// StopRecording stops recording or replaying input.
+func StopRecording() {
+	currentInputPlayer = nil
+}
+
+// Replaying returns true if a recording is being replayed and has not ended; false otherwise.
+func Replaying() bool {
+	return currentInputPlayer != nil && currentInputPlayer.replaying
+}
+
+// advance records or replays the input of the current frame.
+func (player *inputPlayer) advance() {
+	if !player.replaying {
+		player.recording.append(inputState())
+		return
+	}
+
+	runs := player.recording.runs
+	for player.run < len(runs) && player.frame >= runs[player.run].frames {
+		player.run++
+		player.frame = 0
+	}
+	if player.run >= len(runs) {
+		StopRecording()
+		return
+	}
+	copy(IO_RAM[ADDRESS_GAMEPADS:], runs[player.run].state[:])
+	player.frame++
+}
@@ -0,0 +1,137 @@
+//go:build !tinygo
+
+package tic80
+
+import (
+	"strings"
+	"testing"
+)
+
+// testRecording returns a recording with runs of one, 200 and 20000 frames, so that the run lengths take one to three bytes.
+func testRecording() *InputRecording {
+	recording := NewInputRecording()
+	recording.runs = []inputRun{
+		{1, [INPUT_STATE_SIZE]byte{0x01}},
+		{200, [INPUT_STATE_SIZE]byte{0x01, 4: 0x78, 5: 0x44, 8: byte(KEY_A)}},
+		{20000, [INPUT_STATE_SIZE]byte{}},
+	}
+	return recording
+}
+
+func TestInputRecordingBytes(t *testing.T) {
+	recording := testRecording()
+	data := recording.Bytes()
+	expected := []byte{
+		inputVersion,
+		0x01, 0x01, 0x00, 0x01,
+		0xC8, 0x01, 0x30, 0x01, 0x78, 0x44, byte(KEY_A),
+		0xA0, 0x9C, 0x01, 0x31, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x00,
+	}
+	if string(data) != string(expected) {
+		t.Fatalf("encoded as % x, expected % x", data, expected)
+	}
+
+	parsed, err := ParseInputRecording(append(data, 0xFF))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed.runs) != len(recording.runs) {
+		t.Fatalf("parsed %d runs, expected %d", len(parsed.runs), len(recording.runs))
+	}
+	for index, run := range recording.runs {
+		if parsed.runs[index] != run {
+			t.Errorf("run %d is %v, expected %v", index, parsed.runs[index], run)
+		}
+	}
+	if frames := parsed.Frames(); frames != 20201 {
+		t.Errorf("parsed %d frames, expected 20201", frames)
+	}
+}
+
+func TestParseInputRecordingErrors(t *testing.T) {
+	data := testRecording().Bytes()
+	tests := []struct {
+		name  string
+		data  []byte
+		error string
+	}{
+		{"empty", nil, "not an input recording"},
+		{"version", []byte{inputVersion + 1, 0x00}, "not an input recording"},
+		{"no end", data[:len(data)-1], "truncated"},
+		{"run length", data[:6], "truncated"},
+		{"changed", data[:7], "truncated"},
+		{"state", data[:10], "truncated"},
+		{"long run length", []byte{inputVersion, 0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, "truncated"},
+		{"too many frames", []byte{inputVersion, 0x80, 0x80, 0x80, 0x80, 0x08}, "too many frames"},
+	}
+	for _, test := range tests {
+		if _, err := ParseInputRecording(test.data); err == nil || !strings.Contains(err.Error(), test.error) {
+			t.Errorf("%s: error is %v, expected one about %q", test.name, err, test.error)
+		}
+	}
+}
+
+func TestInputRecordingStorage(t *testing.T) {
+	HostReset()
+	recording := testRecording()
+
+	end, err := recording.SavePmem(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 10 + 1 + (len(recording.Bytes())+3)/4; end != expected {
+		t.Errorf("SavePmem ended at %d, expected %d", end, expected)
+	}
+	if loaded, err := LoadInputRecordingPmem(10); err != nil || loaded.Frames() != recording.Frames() {
+		t.Errorf("LoadInputRecordingPmem loaded %v, %v", loaded, err)
+	}
+	if _, err := recording.SavePmem(inputPmemCapacity - 2); err == nil {
+		t.Error("SavePmem saved past the end of persistent memory")
+	}
+
+	end, err = recording.SaveFreeRAM(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := 100 + 4 + len(recording.Bytes()); end != expected {
+		t.Errorf("SaveFreeRAM ended at %d, expected %d", end, expected)
+	}
+	if loaded, err := LoadInputRecordingFreeRAM(100); err != nil || loaded.Frames() != recording.Frames() {
+		t.Errorf("LoadInputRecordingFreeRAM loaded %v, %v", loaded, err)
+	}
+	if _, err := recording.SaveFreeRAM(len(FREE_RAM) - 8); err == nil {
+		t.Error("SaveFreeRAM saved past the end of free memory")
+	}
+
+	// Lengths that would be negative as a 32-bit int must be rejected rather than panic.
+	Pmem(20, 0x80000000)
+	if _, err := LoadInputRecordingPmem(20); err == nil {
+		t.Error("LoadInputRecordingPmem loaded a length past the end of persistent memory")
+	}
+	copy(FREE_RAM[200:], []byte{0x00, 0x00, 0x00, 0x80})
+	if _, err := LoadInputRecordingFreeRAM(200); err == nil {
+		t.Error("LoadInputRecordingFreeRAM loaded a length past the end of free memory")
+	}
+	if _, err := LoadInputRecordingFreeRAM(len(FREE_RAM) - 3); err == nil {
+		t.Error("LoadInputRecordingFreeRAM loaded a length past the end of free memory")
+	}
+}
+
+func TestInputTrace(t *testing.T) {
+	HostReset()
+	var trace strings.Builder
+	previous := HOST_TRACE
+	HOST_TRACE = &trace
+	defer func() { HOST_TRACE = previous }()
+
+	recording := testRecording()
+	recording.Trace()
+	parsed, err := ParseInputTrace("noise\n" + trace.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(parsed.Bytes()) != string(recording.Bytes()) {
+		t.Errorf("parsed % x, expected % x", parsed.Bytes(), recording.Bytes())
+	}
+}
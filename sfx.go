@@ -0,0 +1,181 @@
+package tic80
+
+import "math"
+
+// SfxDefinition defines a sound effect in code, to be written into [tic80.SOUND_EFFECTS] and played with [tic80.Sfx].
+// Each envelope has a value for each of the [tic80.SOUND_EFFECT_TICKS] ticks of the sound effect.
+type SfxDefinition struct {
+	volumes   []int
+	waves     []int
+	arpeggios []int
+	pitches   []int
+	loops     [4][2]int
+	speed     int
+	note      SoundEffectNote
+	octave    int
+	pitch16x  bool
+	reversed  bool
+}
+
+// NewSfxDefinition constructs a [tic80.SfxDefinition] object that plays waveform 0 at full volume for one tick,
+// at [tic80.NOTE_C] in octave 4.
+func NewSfxDefinition() *SfxDefinition {
+	return &SfxDefinition{
+		volumes: []int{15},
+		waves:   []int{0},
+		note:    NOTE_C,
+		octave:  4,
+	}
+}
+
+// SetVolumes sets the volume at each tick, from 0 (silent) to 15.
+// The ticks after the last volume are silent.
+func (definition *SfxDefinition) SetVolumes(levels ...int) *SfxDefinition {
+	definition.volumes = append([]int(nil), levels...)
+	return definition
+}
+
+// SetWaves sets the waveform id at each tick.
+// The ticks after the last id keep playing it.
+func (definition *SfxDefinition) SetWaves(ids ...int) *SfxDefinition {
+	definition.waves = append([]int(nil), ids...)
+	return definition
+}
+
+// SetArpeggios sets the arpeggio offset in semitones at each tick, from 0 to 15.
+// The ticks after the last offset keep it.
+func (definition *SfxDefinition) SetArpeggios(offsets ...int) *SfxDefinition {
+	definition.arpeggios = append([]int(nil), offsets...)
+	return definition
+}
+
+// SetPitches sets the pitch offset at each tick, from -8 to 7.
+// The ticks after the last offset keep it.
+func (definition *SfxDefinition) SetPitches(offsets ...int) *SfxDefinition {
+	definition.pitches = append([]int(nil), offsets...)
+	return definition
+}
+
+// SetLoop sets the first tick and the number of ticks of the loop of the specified envelope, from 0 to 15.
+// A size of 0 means that the envelope does not loop.
+func (definition *SfxDefinition) SetLoop(envelope SoundEffectEnvelope, start, size int) *SfxDefinition {
+	definition.loops[envelope%4] = [2]int{start % 16, size % 16}
+	return definition
+}
+
+// SetSpeed sets the speed.
+func (definition *SfxDefinition) SetSpeed(speed int) *SfxDefinition {
+	if speed < -4 {
+		definition.speed = -4
+	} else if speed > 3 {
+		definition.speed = 3
+	} else {
+		definition.speed = speed
+	}
+	return definition
+}
+
+// SetNote sets the base note and octave, which [tic80.Sfx] plays when no note is set.
+func (definition *SfxDefinition) SetNote(note SoundEffectNote, octave int) *SfxDefinition {
+	definition.note = note % 12
+	definition.octave = octave % 8
+	return definition
+}
+
+// TogglePitch16x toggles whether the pitch offsets are multiplied by 16.
+func (definition *SfxDefinition) TogglePitch16x() *SfxDefinition {
+	definition.pitch16x = !definition.pitch16x
+	return definition
+}
+
+// ToggleReversed toggles whether the arpeggio offsets are subtracted rather than added.
+func (definition *SfxDefinition) ToggleReversed() *SfxDefinition {
+	definition.reversed = !definition.reversed
+	return definition
+}
+
+// envelopeValue returns the value of an envelope at a tick, which keeps its last value, or the default if it has none.
+func envelopeValue(values []int, tick, defaultValue int) int {
+	if len(values) == 0 {
+		return defaultValue
+	}
+	if tick >= len(values) {
+		return values[len(values)-1]
+	}
+	return values[tick]
+}
+
+// Write writes the sound effect into [tic80.SOUND_EFFECTS] at the specified id.
+func (definition *SfxDefinition) Write(id int) {
+	definition.WriteTo(SOUND_EFFECTS.SoundEffect(id))
+}
+
+// WriteTo writes the sound effect into a sound effect view, such as one of a cartridge.
+func (definition *SfxDefinition) WriteTo(effect *SoundEffect) {
+	*effect = SoundEffect{}
+	for tick := 0; tick < SOUND_EFFECT_TICKS; tick++ {
+		level := 0
+		if tick < len(definition.volumes) {
+			level = definition.volumes[tick]
+		}
+		effect.SetVolume(tick, 15-level%16)
+		effect.SetWave(tick, envelopeValue(definition.waves, tick, 0))
+		effect.SetArpeggio(tick, envelopeValue(definition.arpeggios, tick, 0))
+		effect.SetPitch(tick, envelopeValue(definition.pitches, tick, 0))
+	}
+	for envelope, loop := range definition.loops {
+		effect.SetLoop(SoundEffectEnvelope(envelope), loop[0], loop[1])
+	}
+	effect.SetSpeed(definition.speed)
+	effect.SetNote(definition.note)
+	effect.SetOctave(definition.octave)
+	effect.SetPitch16x(definition.pitch16x)
+	effect.SetReversed(definition.reversed)
+}
+
+// generate sets each sample of the waveform from a function of its phase, from 0 to 1, that returns a level from 0 to 1.
+func (waveform *Waveform) generate(function func(phase float64) float64) {
+	for index := 0; index < 32; index++ {
+		level := function(float64(index) / 32)
+		waveform.SetSample(index, int(math.Round(math.Max(0, math.Min(1, level))*15)))
+	}
+}
+
+// GenerateSine sets the waveform to a sine wave.
+func (waveform *Waveform) GenerateSine() {
+	waveform.generate(func(phase float64) float64 {
+		return (1 + math.Sin(2*math.Pi*phase)) / 2
+	})
+}
+
+// GenerateSquare sets the waveform to a square wave that is high for the specified fraction of its period, from 0 to 1.
+// A duty of 0.5 is a square wave, and smaller duties sound thinner.
+func (waveform *Waveform) GenerateSquare(duty float64) {
+	waveform.generate(func(phase float64) float64 {
+		if phase < duty {
+			return 1
+		}
+		return 0
+	})
+}
+
+// GenerateSaw sets the waveform to a rising sawtooth wave.
+func (waveform *Waveform) GenerateSaw() {
+	waveform.generate(func(phase float64) float64 {
+		return phase * 32 / 31
+	})
+}
+
+// GenerateTriangle sets the waveform to a triangle wave.
+func (waveform *Waveform) GenerateTriangle() {
+	waveform.generate(func(phase float64) float64 {
+		return 1 - math.Abs(phase*2-1)
+	})
+}
+
+// GenerateNoise sets the waveform to noise.
+// TIC-80 plays a silent waveform as white noise, so the waveform is cleared rather than filled with random samples,
+// which would repeat every period and sound like a tone.
+func (waveform *Waveform) GenerateNoise() {
+	*waveform = Waveform{}
+}
@@ -0,0 +1,503 @@
+//go:build !tinygo
+
+package tic80
+
+import "math"
+
+// Sound Output
+const (
+	HOST_SAMPLE_RATE = 44100
+	HOST_FRAME_RATE  = 60
+)
+
+// Synthesizer Constants
+const (
+	musicTicksPerRow  = 900
+	musicDefaultSpeed = 6
+	soundMaximumNote  = 8*12 - 1
+	soundMaximumLevel = 15
+)
+
+// soundChannel is the state of a sound effect playing in one channel.
+type soundChannel struct {
+	id       int
+	note     int
+	duration int
+	speed    int
+	tick     int
+	left     int
+	right    int
+}
+
+// musicChannel is the state of the music playing in one channel.
+type musicChannel struct {
+	sound         soundChannel
+	pitch         int
+	chord         int
+	chordTick     int
+	vibratoPeriod int
+	vibratoDepth  int
+	slideTicks    int
+	slideFrom     int
+	slideTick     int
+	delay         int
+	delayed       MusicRow
+}
+
+// soundState is the state of the sound of the host that TIC-80 keeps outside of RAM.
+type soundState struct {
+	effects  [4]soundChannel
+	music    [4]musicChannel
+	tempo    int
+	speed    int
+	ticks    int
+	jumped   bool
+	phases   [4]float64
+	noise    [4]uint16
+	samples  []int16
+	fraction float64
+}
+
+var sound soundState
+
+// resetSound stops all sound effects and music.
+func resetSound() {
+	sound = soundState{}
+	for channel := range sound.effects {
+		sound.effects[channel].id = -1
+		sound.noise[channel] = 1
+	}
+	resetMusicChannels()
+}
+
+// resetMusicChannels stops the notes of the music and restores the volume of each channel.
+func resetMusicChannels() {
+	for channel := range sound.music {
+		sound.music[channel] = musicChannel{}
+		sound.music[channel].sound = soundChannel{id: -1, left: soundMaximumLevel, right: soundMaximumLevel}
+	}
+}
+
+// HostAudio returns the interleaved stereo samples synthesized by [tic80.HostAdvance] since it was last called,
+// at [tic80.HOST_SAMPLE_RATE].
+func HostAudio() []int16 {
+	samples := sound.samples
+	sound.samples = nil
+	return samples
+}
+
+// HostSoundPlaying returns true if music is playing, or a sound effect is playing that has not ended; false otherwise.
+// A sound effect that sustains a sound at its last tick plays until its duration ends.
+func HostSoundPlaying() bool {
+	if MUSIC_STATE.Playing() {
+		return true
+	}
+	for channel := range sound.effects {
+		if soundActive(&sound.effects[channel]) {
+			return true
+		}
+	}
+	return false
+}
+
+// soundActive returns true if the sound effect of a channel is playing and can still be heard,
+// from the tick in the registers onwards.
+func soundActive(channel *soundChannel) bool {
+	if channel.id < 0 {
+		return false
+	}
+	if channel.duration >= 0 {
+		return true
+	}
+	effect := SOUND_EFFECTS.SoundEffect(channel.id)
+	if _, size := effect.Loop(ENVELOPE_VOLUME); size > 0 {
+		return true
+	}
+	for tick := soundPosition(channel.speed, channel.tick); tick < SOUND_EFFECT_TICKS; tick++ {
+		if tick < 0 {
+			continue
+		}
+		if effect.Volume(tick) < soundMaximumLevel {
+			return true
+		}
+	}
+	return effect.Volume(SOUND_EFFECT_TICKS-1) < soundMaximumLevel
+}
+
+// soundPosition returns the tick of the envelopes that a sound effect is at, after it has played for the given number of ticks.
+func soundPosition(speed, ticks int) int {
+	if speed > 0 {
+		return ticks * (1 + speed)
+	}
+	return ticks / (1 - speed)
+}
+
+// loopPosition returns the tick of an envelope at the given position, following its loop.
+func loopPosition(start, size, position int) int {
+	if size == 0 {
+		if position >= SOUND_EFFECT_TICKS {
+			return SOUND_EFFECT_TICKS - 1
+		}
+		return position
+	}
+	if end := start + size; position >= end {
+		return start + (position-start)%size
+	}
+	return position
+}
+
+// advanceSound plays the next tick of a sound effect into the registers of a channel.
+// The pitch is added to the frequency in hertz, and the arpeggio is added to the note in semitones.
+func advanceSound(channel *soundChannel, index int, pitch float64, arpeggio int) {
+	if channel.duration > 0 {
+		channel.duration--
+	}
+	if channel.id < 0 || channel.duration == 0 {
+		channel.id = -1
+		return
+	}
+	channel.tick++
+
+	effect := SOUND_EFFECTS.SoundEffect(channel.id)
+	position := soundPosition(channel.speed, channel.tick)
+	tick := func(envelope SoundEffectEnvelope) int {
+		start, size := effect.Loop(envelope)
+		return loopPosition(start, size, position)
+	}
+
+	volume := soundMaximumLevel - effect.Volume(tick(ENVELOPE_VOLUME))
+	if volume <= 0 {
+		return
+	}
+	offset := effect.Arpeggio(tick(ENVELOPE_ARPEGGIO))
+	if effect.Reversed() {
+		offset = -offset
+	}
+	note := channel.note + offset + arpeggio
+	if note < 0 {
+		note = 0
+	} else if note > soundMaximumNote {
+		note = soundMaximumNote
+	}
+	bend := effect.Pitch(tick(ENVELOPE_PITCH))
+	if effect.Pitch16x() {
+		bend *= 16
+	}
+	frequency := int(math.Round(noteFrequency(note) + float64(bend) + pitch))
+	if frequency < 0 {
+		frequency = 0
+	}
+
+	register := SOUND_REGISTERS.Channel(index)
+	register.SetFrequency(frequency)
+	register.SetVolume(volume)
+	*register.Waveform() = *WAVEFORMS.Waveform(effect.Wave(tick(ENVELOPE_WAVE)))
+
+	left, right := channel.left, channel.right
+	mutedLeft, mutedRight := effect.Muted()
+	if mutedLeft {
+		left = 0
+	}
+	if mutedRight {
+		right = 0
+	}
+	STEREO_VOLUME.SetVolume(index, left, right)
+}
+
+func rawSfx(id, note, octave, duration, channel, volumeLeft, volumeRight, speed int32) {
+	if channel < 0 || channel >= 4 {
+		return
+	}
+	playing := &sound.effects[channel]
+	if id < 0 {
+		playing.id = -1
+		return
+	}
+	effect := SOUND_EFFECTS.SoundEffect(int(id))
+	if note < 0 {
+		note = int32(effect.Note())
+		octave = int32(effect.Octave())
+	}
+	*playing = soundChannel{
+		id:       int(id % 64),
+		note:     int(octave*12 + note),
+		duration: int(duration),
+		speed:    int(speed),
+		tick:     -1,
+		left:     int(volumeLeft),
+		right:    int(volumeRight),
+	}
+}
+
+func rawMusic(track, frame, row int32, loop, sustain bool, tempo, speed int32) {
+	resetMusicChannels()
+	if track < 0 {
+		MUSIC_STATE[0], MUSIC_STATE[1], MUSIC_STATE[2], MUSIC_STATE[3] = 0xFF, 0xFF, 0xFF, 0
+		return
+	}
+
+	if frame < 0 {
+		frame = 0
+	}
+	if row < 0 {
+		row = 0
+	}
+	playing := MUSIC_TRACKS.Track(int(track))
+	sound.tempo = playing.Tempo()
+	if tempo >= 0 {
+		sound.tempo = int(tempo)
+	}
+	sound.speed = playing.Speed()
+	if speed >= 0 {
+		sound.speed = int(speed)
+	}
+	if sound.speed <= 0 {
+		sound.speed = musicDefaultSpeed
+	}
+	sound.ticks = musicTicks(int(row))
+	sound.jumped = true
+
+	MUSIC_STATE[0] = byte(track % MUSIC_TRACK_COUNT)
+	MUSIC_STATE[1] = byte(frame % MUSIC_FRAMES)
+	MUSIC_STATE[2] = byte(row % MUSIC_ROWS)
+	MUSIC_STATE[3] = 0
+	if loop {
+		MUSIC_STATE[3] |= 0x01
+	}
+	if sustain {
+		MUSIC_STATE[3] |= 0x02
+	}
+	MUSIC_STATE.SetPlaying(true)
+}
+
+// musicTicks returns the first tick of a row, at the tempo and speed of the music.
+func musicTicks(row int) int {
+	return (row*musicTicksPerRow*sound.speed + sound.tempo*musicDefaultSpeed - 1) / (sound.tempo * musicDefaultSpeed)
+}
+
+// musicRow returns the row that the music is at, after it has played for the given number of ticks.
+func musicRow(ticks int) int {
+	return ticks * sound.tempo * musicDefaultSpeed / sound.speed / musicTicksPerRow
+}
+
+// stopMusic stops the music from playing.
+func stopMusic() {
+	rawMusic(-1, -1, -1, false, false, -1, -1)
+}
+
+// advanceMusic plays the next tick of the music into the registers.
+func advanceMusic() {
+	if !MUSIC_STATE.Playing() || MUSIC_STATE.Track() < 0 {
+		return
+	}
+	track := MUSIC_TRACKS.Track(MUSIC_STATE.Track())
+	frame := MUSIC_STATE.Frame()
+	row := MUSIC_STATE.Row()
+
+	if sound.jumped {
+		sound.jumped = false
+		if track.Frame(frame) == [MUSIC_CHANNELS]int{} {
+			stopMusic()
+			return
+		}
+		playRows(track, frame, row)
+	} else if next := musicRow(sound.ticks); next != row {
+		row = next
+		if row >= track.Rows() {
+			row = 0
+			frame++
+			if !MUSIC_STATE.Sustaining() {
+				resetMusicChannels()
+			}
+			if frame >= MUSIC_FRAMES || track.Frame(frame) == [MUSIC_CHANNELS]int{} {
+				if !MUSIC_STATE.Looping() {
+					stopMusic()
+					return
+				}
+				frame = 0
+			}
+			sound.ticks = 0
+		}
+		MUSIC_STATE[1], MUSIC_STATE[2] = byte(frame), byte(row)
+		playRows(track, frame, row)
+	}
+
+	for index := range sound.music {
+		playMusicChannel(&sound.music[index], index)
+	}
+	sound.ticks++
+}
+
+// playRows plays the rows of a frame in each channel.
+func playRows(track *MusicTrack, frame, row int) {
+	for index := range sound.music {
+		id := track.Pattern(frame, index)
+		if id < 1 || id > MUSIC_PATTERN_COUNT {
+			continue
+		}
+		playRow(&sound.music[index], MUSIC_PATTERNS.Pattern(id).Row(row))
+	}
+}
+
+// playRow plays the note and command of a row in a channel.
+func playRow(channel *musicChannel, row *MusicRow) {
+	argument := row.Argument()
+	x, y := argument>>4, argument&0x0F
+
+	switch row.Command() {
+	case MUSIC_COMMAND_VOLUME:
+		channel.sound.left, channel.sound.right = x, y
+	case MUSIC_COMMAND_CHORD:
+		channel.chord = argument
+		channel.chordTick = 0
+	case MUSIC_COMMAND_JUMP:
+		if x < MUSIC_FRAMES && y < MUSIC_ROWS {
+			MUSIC_STATE[1], MUSIC_STATE[2] = byte(x), byte(y)
+			sound.ticks = musicTicks(y)
+			sound.jumped = true
+		}
+	case MUSIC_COMMAND_SLIDE:
+		channel.slideTicks = argument
+	case MUSIC_COMMAND_PITCH:
+		channel.pitch = argument - 0x80
+	case MUSIC_COMMAND_VIBRATO:
+		channel.vibratoPeriod, channel.vibratoDepth = x, y
+	case MUSIC_COMMAND_DELAY:
+		if row.Note() >= musicNoteFirst {
+			channel.delay = argument
+			channel.delayed = *row
+			return
+		}
+	}
+	playNote(channel, row)
+}
+
+// playNote starts or stops the note of a row in a channel.
+func playNote(channel *musicChannel, row *MusicRow) {
+	switch note := row.Note(); {
+	case note == musicNoteStop:
+		channel.sound.id = -1
+	case note >= musicNoteFirst:
+		id := row.SoundEffect()
+		previous := channel.sound.note
+		wasPlaying := channel.sound.id >= 0
+		channel.sound = soundChannel{
+			id:       id,
+			note:     row.Octave()*12 + note - musicNoteFirst,
+			duration: -1,
+			speed:    SOUND_EFFECTS.SoundEffect(id).Speed(),
+			tick:     -1,
+			left:     channel.sound.left,
+			right:    channel.sound.right,
+		}
+		channel.slideTick = 0
+		channel.slideFrom = channel.sound.note
+		if wasPlaying && channel.slideTicks > 0 {
+			channel.slideFrom = previous
+		}
+	}
+}
+
+// playMusicChannel plays the next tick of the music in a channel.
+func playMusicChannel(channel *musicChannel, index int) {
+	if channel.delay > 0 {
+		channel.delay--
+		if channel.delay == 0 {
+			playNote(channel, &channel.delayed)
+		}
+	}
+	if channel.sound.id < 0 {
+		return
+	}
+
+	pitch := float64(channel.pitch)
+	note := float64(channel.sound.note)
+	if channel.slideTicks > 0 && channel.slideTick < channel.slideTicks {
+		from := float64(channel.slideFrom)
+		note = from + (note-from)*float64(channel.slideTick)/float64(channel.slideTicks)
+		channel.slideTick++
+	}
+	if channel.vibratoPeriod > 0 && channel.vibratoDepth > 0 {
+		phase := 2 * math.Pi * float64(sound.ticks) / float64(channel.vibratoPeriod*4)
+		note += math.Sin(phase) * float64(channel.vibratoDepth) / 16
+	}
+	pitch += noteFrequency(int(math.Floor(note))) * (math.Pow(2, (note-math.Floor(note))/12) - 1)
+
+	arpeggio := 0
+	if channel.chord != 0 {
+		switch channel.chordTick % 3 {
+		case 1:
+			arpeggio = channel.chord >> 4
+		case 2:
+			arpeggio = channel.chord & 0x0F
+		}
+		channel.chordTick++
+	}
+
+	target := channel.sound.note
+	channel.sound.note = int(math.Floor(note))
+	advanceSound(&channel.sound, index, pitch, arpeggio)
+	channel.sound.note = target
+}
+
+// advanceAudio synthesizes one frame of sound from the registers,
+// then plays the next tick of the music and the sound effects into the registers.
+func advanceAudio() {
+	synthesize()
+
+	*SOUND_REGISTERS = SoundRegisterMemory{}
+	for index := range STEREO_VOLUME {
+		STEREO_VOLUME[index] = 0xFF
+	}
+	advanceMusic()
+	for index := range sound.effects {
+		advanceSound(&sound.effects[index], index, 0, 0)
+	}
+}
+
+// noiseWaveform returns true if a waveform is silent, which TIC-80 plays as noise.
+func noiseWaveform(waveform *Waveform) bool {
+	return *waveform == Waveform{}
+}
+
+// synthesize appends one frame of samples synthesized from the registers and stereo volumes.
+func synthesize() {
+	sound.fraction += float64(HOST_SAMPLE_RATE) / HOST_FRAME_RATE
+	count := int(sound.fraction)
+	sound.fraction -= float64(count)
+
+	const amplitude = math.MaxInt16 / 4 / soundMaximumLevel / soundMaximumLevel
+	for sample := 0; sample < count; sample++ {
+		var left, right int
+		for index := range SOUND_REGISTERS {
+			register := SOUND_REGISTERS.Channel(index)
+			frequency := float64(register.Frequency())
+			volume := register.Volume()
+			if frequency == 0 || volume == 0 {
+				continue
+			}
+
+			waveform := register.Waveform()
+			phase := sound.phases[index]
+			var value int
+			if noiseWaveform(waveform) {
+				previous := int(phase * 32)
+				phase += frequency / HOST_SAMPLE_RATE
+				for step := previous; step < int(phase*32); step++ {
+					bit := (sound.noise[index] ^ sound.noise[index]>>1) & 1
+					sound.noise[index] = sound.noise[index]>>1 | bit<<14
+				}
+				value = int(sound.noise[index]&1)*soundMaximumLevel*2 - soundMaximumLevel
+			} else {
+				phase += frequency / HOST_SAMPLE_RATE
+				value = waveform.Sample(int(phase*32)%32)*2 - soundMaximumLevel
+			}
+			sound.phases[index] = phase - math.Floor(phase)
+
+			leftLevel, rightLevel := STEREO_VOLUME.Volume(index)
+			left += value * volume * leftLevel / soundMaximumLevel
+			right += value * volume * rightLevel / soundMaximumLevel
+		}
+		sound.samples = append(sound.samples, int16(left*amplitude), int16(right*amplitude))
+	}
+}
@@ -0,0 +1,86 @@
+package spritesheet
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/sorucoder/tic80"
+)
+
+// nearestColor returns the index of the color of the palette closest to the given components.
+func nearestColor(palette tic80.Palette, red, green, blue int) int {
+	nearest, nearestDistance := 0, -1
+	for index, entry := range palette {
+		redDistance := red - int(entry.R)
+		greenDistance := green - int(entry.G)
+		blueDistance := blue - int(entry.B)
+		// Weigh the components roughly by how sensitive the eye is to each of them.
+		distance := 3*redDistance*redDistance + 4*greenDistance*greenDistance + 2*blueDistance*blueDistance
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearest, nearestDistance = index, distance
+		}
+	}
+	return nearest
+}
+
+// clampComponent clamps a color component to the range of 0 to 255.
+func clampComponent(value int) int {
+	if value < 0 {
+		return 0
+	} else if value > 0xFF {
+		return 0xFF
+	}
+	return value
+}
+
+// Quantize maps each pixel of the picture to the nearest color of the palette.
+// Pixels that are more than half transparent are mapped to the transparent color.
+// If dither is true, the error of each pixel is diffused to its neighbors with Floyd-Steinberg dithering.
+func Quantize(picture image.Image, palette tic80.Palette, dither bool, transparentColor int) *image.Paletted {
+	bounds := picture.Bounds()
+	colors := make(color.Palette, len(palette))
+	for index, entry := range palette {
+		colors[index] = entry
+	}
+	quantized := image.NewPaletted(image.Rect(0, 0, bounds.Dx(), bounds.Dy()), colors)
+
+	width := bounds.Dx()
+	diffusion := make([][3]int, width*2+2)
+	current, next := diffusion[:width+1], diffusion[width+1:]
+	for y := 0; y < bounds.Dy(); y++ {
+		for index := range next {
+			next[index] = [3]int{}
+		}
+		for x := 0; x < width; x++ {
+			pixel := color.NRGBAModel.Convert(picture.At(bounds.Min.X+x, bounds.Min.Y+y)).(color.NRGBA)
+			if pixel.A < 0x80 {
+				quantized.SetColorIndex(x, y, uint8(transparentColor%16))
+				continue
+			}
+
+			components := [3]int{int(pixel.R), int(pixel.G), int(pixel.B)}
+			if dither {
+				for channel := range components {
+					components[channel] = clampComponent(components[channel] + current[x][channel]/16)
+				}
+			}
+			index := nearestColor(palette, components[0], components[1], components[2])
+			quantized.SetColorIndex(x, y, uint8(index))
+
+			if dither {
+				entry := palette[index]
+				difference := [3]int{components[0] - int(entry.R), components[1] - int(entry.G), components[2] - int(entry.B)}
+				for channel, amount := range difference {
+					current[x+1][channel] += amount * 7
+					if x > 0 {
+						next[x-1][channel] += amount * 3
+					}
+					next[x][channel] += amount * 5
+					next[x+1][channel] += amount
+				}
+			}
+		}
+		current, next = next, current
+	}
+	return quantized
+}
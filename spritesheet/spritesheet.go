@@ -0,0 +1,293 @@
+// Package spritesheet imports images into the tiles and sprites of a cartridge.
+//
+// An image is quantized to the 16 colors of the palette, split into 8x8 tiles, and packed into a bank of
+// [tic80.TileMemory], so that each sprite can be drawn with [tic80.Spr] and [tic80.SpriteOptions.SetSize].
+package spritesheet
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"image"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/sorucoder/tic80"
+)
+
+// Sheet Dimensions
+const (
+	TILE_SIZE      = 8
+	SHEET_COLUMNS  = 16
+	SHEET_ROWS     = 16
+	SPRITES_OFFSET = 256
+)
+
+// Region is a named sprite of a sheet, measured in tiles.
+type Region struct {
+	Name   string
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// Sprite is a sprite that has been imported into a bank.
+type Sprite struct {
+	Name   string
+	ID     int
+	Width  int
+	Height int
+}
+
+// ImportOptions provides additional options to [spritesheet.Import].
+type ImportOptions struct {
+	sprites bool
+	first   int
+}
+
+var defaultImportOptions ImportOptions = ImportOptions{
+	sprites: false,
+	first:   0,
+}
+
+// NewImportOptions constructs a [spritesheet.ImportOptions] object with the defaults.
+func NewImportOptions() *ImportOptions {
+	options := new(ImportOptions)
+	*options = defaultImportOptions
+	return options
+}
+
+// ToggleSprites toggles whether the bank is the sprites, whose ids start at 256, or the tiles.
+func (options *ImportOptions) ToggleSprites() *ImportOptions {
+	options.sprites = !options.sprites
+	return options
+}
+
+// SetFirst sets the index in the bank of the first tile that may be written to.
+func (options *ImportOptions) SetFirst(first int) *ImportOptions {
+	options.first = first % (SHEET_COLUMNS * SHEET_ROWS)
+	return options
+}
+
+// ParseRegions parses regions from text, with one region per line of the form "name x y width height".
+// Blank lines and lines starting with # are ignored.
+func ParseRegions(reader io.Reader) ([]Region, error) {
+	var regions []Region
+	scanner := bufio.NewScanner(reader)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("spritesheet: line %d: expected name x y width height", line)
+		}
+		var numbers [4]int
+		for index := range numbers {
+			number, err := strconv.Atoi(fields[index+1])
+			if err != nil || number < 0 {
+				return nil, fmt.Errorf("spritesheet: line %d: %q is not a valid number", line, fields[index+1])
+			}
+			numbers[index] = number
+		}
+		regions = append(regions, Region{fields[0], numbers[0], numbers[1], numbers[2], numbers[3]})
+	}
+	return regions, scanner.Err()
+}
+
+// GridRegions returns a region for each tile of the sheet that is not entirely the transparent color,
+// named after its column and row.
+func GridRegions(sheet *image.Paletted, transparentColor int) []Region {
+	var regions []Region
+	for y := 0; y < sheet.Bounds().Dy()/TILE_SIZE; y++ {
+		for x := 0; x < sheet.Bounds().Dx()/TILE_SIZE; x++ {
+			tile := cutTile(sheet, x, y)
+			if tile != solidTile(transparentColor) {
+				regions = append(regions, Region{fmt.Sprintf("TILE_%d_%d", x, y), x, y, 1, 1})
+			}
+		}
+	}
+	return regions
+}
+
+// cutTile returns the tile at the given tile coordinates of the sheet.
+func cutTile(sheet *image.Paletted, x, y int) (tile tic80.Tile) {
+	bounds := sheet.Bounds()
+	for tileY := 0; tileY < TILE_SIZE; tileY++ {
+		for tileX := 0; tileX < TILE_SIZE; tileX++ {
+			tile.SetPixel(tileX, tileY, int(sheet.ColorIndexAt(bounds.Min.X+x*TILE_SIZE+tileX, bounds.Min.Y+y*TILE_SIZE+tileY)))
+		}
+	}
+	return
+}
+
+// solidTile returns a tile of only one color.
+func solidTile(color int) (tile tic80.Tile) {
+	for index := range tile {
+		tile[index] = byte(color&0x0F) * 0x11
+	}
+	return
+}
+
+// placement is where the tiles of a region were placed in a bank.
+type placement struct {
+	index  int
+	width  int
+	height int
+	tiles  []tic80.Tile
+}
+
+// Import splits each region of the sheet into tiles, and writes them to the bank.
+// The tiles of each region are kept together in the 16x16 grid of the bank, so that they can be drawn as one sprite.
+// Regions whose tiles are identical to those of an earlier region share its tiles, so repeated tiles are only stored once.
+// It returns the sprites in the same order as the regions.
+func Import(bank *tic80.TileMemory, sheet *image.Paletted, regions []Region, options *ImportOptions) ([]Sprite, error) {
+	if options == nil {
+		options = &defaultImportOptions
+	}
+
+	var used [SHEET_ROWS][SHEET_COLUMNS]bool
+	for index := 0; index < options.first; index++ {
+		used[index/SHEET_COLUMNS][index%SHEET_COLUMNS] = true
+	}
+
+	columns, rows := sheet.Bounds().Dx()/TILE_SIZE, sheet.Bounds().Dy()/TILE_SIZE
+	var placements []placement
+	sprites := make([]Sprite, 0, len(regions))
+	for _, region := range regions {
+		if region.Width < 1 || region.Height < 1 || region.X+region.Width > columns || region.Y+region.Height > rows {
+			return nil, fmt.Errorf("spritesheet: region %s is outside of the sheet", region.Name)
+		}
+
+		tiles := make([]tic80.Tile, 0, region.Width*region.Height)
+		for y := region.Y; y < region.Y+region.Height; y++ {
+			for x := region.X; x < region.X+region.Width; x++ {
+				tiles = append(tiles, cutTile(sheet, x, y))
+			}
+		}
+
+		index, found := findPlacement(placements, region.Width, region.Height, tiles)
+		if !found {
+			index, found = allocate(&used, region.Width, region.Height)
+			if !found {
+				return nil, fmt.Errorf("spritesheet: there is no room in the bank for region %s", region.Name)
+			}
+			for offset, tile := range tiles {
+				*bank.Tile(index + offset/region.Width*SHEET_COLUMNS + offset%region.Width) = tile
+			}
+			placements = append(placements, placement{index, region.Width, region.Height, tiles})
+		}
+
+		id := index
+		if options.sprites {
+			id += SPRITES_OFFSET
+		}
+		sprites = append(sprites, Sprite{region.Name, id, region.Width, region.Height})
+	}
+	return sprites, nil
+}
+
+// findPlacement returns the index of an earlier placement of the same tiles.
+// A single tile may also be found within a larger placement.
+func findPlacement(placements []placement, width, height int, tiles []tic80.Tile) (int, bool) {
+	for _, placed := range placements {
+		if width == 1 && height == 1 {
+			for offset, tile := range placed.tiles {
+				if tile == tiles[0] {
+					return placed.index + offset/placed.width*SHEET_COLUMNS + offset%placed.width, true
+				}
+			}
+			continue
+		}
+		if placed.width != width || placed.height != height {
+			continue
+		}
+		same := true
+		for index := range tiles {
+			if tiles[index] != placed.tiles[index] {
+				same = false
+				break
+			}
+		}
+		if same {
+			return placed.index, true
+		}
+	}
+	return 0, false
+}
+
+// allocate marks the first free block of the given size in the grid as used, and returns the index of its top left tile.
+func allocate(used *[SHEET_ROWS][SHEET_COLUMNS]bool, width, height int) (int, bool) {
+	for y := 0; y+height <= SHEET_ROWS; y++ {
+		for x := 0; x+width <= SHEET_COLUMNS; x++ {
+			if blockFree(used, x, y, width, height) {
+				for blockY := y; blockY < y+height; blockY++ {
+					for blockX := x; blockX < x+width; blockX++ {
+						used[blockY][blockX] = true
+					}
+				}
+				return y*SHEET_COLUMNS + x, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// blockFree returns true if no tile of the block is used; false otherwise.
+func blockFree(used *[SHEET_ROWS][SHEET_COLUMNS]bool, x, y, width, height int) bool {
+	for blockY := y; blockY < y+height; blockY++ {
+		for blockX := x; blockX < x+width; blockX++ {
+			if used[blockY][blockX] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// constantName transforms a name into an exported Go constant name in the style of this library.
+func constantName(name string) string {
+	var builder strings.Builder
+	for _, character := range name {
+		switch {
+		case unicode.IsLetter(character) || unicode.IsDigit(character):
+			builder.WriteRune(unicode.ToUpper(character))
+		default:
+			builder.WriteRune('_')
+		}
+	}
+	constant := builder.String()
+	if constant == "" || !unicode.IsLetter([]rune(constant)[0]) {
+		constant = "SPRITE_" + constant
+	}
+	return constant
+}
+
+// WriteConstants writes a Go source file to the writer that declares the id, width and height of each sprite as constants.
+func WriteConstants(writer io.Writer, packageName string, sprites []Sprite) error {
+	var source bytes.Buffer
+	fmt.Fprintf(&source, "// Code generated by tic80 sprites; DO NOT EDIT.\n\npackage %s\n\n// Sprites\nconst (\n", packageName)
+	declared := make(map[string]bool, len(sprites))
+	for _, sprite := range sprites {
+		name := constantName(sprite.Name)
+		if declared[name] {
+			return fmt.Errorf("spritesheet: sprite %s is declared more than once", name)
+		}
+		declared[name] = true
+		fmt.Fprintf(&source, "%s = %d\n%s_WIDTH = %d\n%s_HEIGHT = %d\n", name, sprite.ID, name, sprite.Width, name, sprite.Height)
+	}
+	source.WriteString(")\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return errors.New("spritesheet: could not format the generated source")
+	}
+	_, err = writer.Write(formatted)
+	return err
+}
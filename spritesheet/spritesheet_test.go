@@ -0,0 +1,133 @@
+package spritesheet
+
+import (
+	"image"
+	_ "image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/sorucoder/tic80"
+)
+
+// readSheet decodes a PNG of the testdata directory.
+func readSheet(t *testing.T, name string) image.Image {
+	t.Helper()
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	picture, _, err := image.Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return picture
+}
+
+// sweetie returns the default palette.
+func sweetie(t *testing.T) tic80.Palette {
+	t.Helper()
+	palette, err := tic80.ParsePalette(tic80.SWEETIE_16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return palette
+}
+
+// The indexed sheet is three tiles wide, whose pixels are (x+y)%16 in the first and last tiles and 0 in the middle tile.
+func TestImportIndexed(t *testing.T) {
+	picture := readSheet(t, "indexed.png")
+	if _, ok := picture.(*image.Paletted); !ok {
+		t.Fatalf("indexed.png decoded as %T, want *image.Paletted", picture)
+	}
+	sheet := Quantize(picture, sweetie(t), false, 0)
+	if sheet.Bounds() != image.Rect(0, 0, 24, 8) {
+		t.Fatalf("sheet bounds = %v, want 24x8", sheet.Bounds())
+	}
+
+	var pattern tic80.Tile
+	for y := 0; y < TILE_SIZE; y++ {
+		for x := 0; x < TILE_SIZE; x++ {
+			pattern.SetPixel(x, y, (x+y)%16)
+		}
+	}
+	for column, want := range []tic80.Tile{pattern, {}, pattern} {
+		if tile := cutTile(sheet, column, 0); tile != want {
+			t.Errorf("tile %d = %x, want %x", column, tile, want)
+		}
+	}
+
+	wantGrid := []Region{{"TILE_0_0", 0, 0, 1, 1}, {"TILE_2_0", 2, 0, 1, 1}}
+	if regions := GridRegions(sheet, 0); !reflect.DeepEqual(regions, wantGrid) {
+		t.Errorf("GridRegions = %v, want %v", regions, wantGrid)
+	}
+
+	regions, err := ParseRegions(strings.NewReader("# name x y width height\nwide 0 0 2 1\n\nfirst 0 0 1 1\ncopy 2 0 1 1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var bank tic80.TileMemory
+	*bank.Tile(0) = solidTile(7)
+	sprites, err := Import(&bank, sheet, regions, NewImportOptions().SetFirst(1).ToggleSprites())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantSprites := []Sprite{{"wide", 257, 2, 1}, {"first", 257, 1, 1}, {"copy", 257, 1, 1}}
+	if !reflect.DeepEqual(sprites, wantSprites) {
+		t.Errorf("Import = %v, want %v", sprites, wantSprites)
+	}
+	for index, want := range []tic80.Tile{solidTile(7), pattern, {}, {}} {
+		if tile := *bank.Tile(index); tile != want {
+			t.Errorf("bank tile %d = %x, want %x", index, tile, want)
+		}
+	}
+}
+
+// The RGBA sheet has the colors of the palette in its first two rows, slightly different colors in its third,
+// mostly transparent white in its fourth, and opaque white in the rest.
+func TestQuantizeRGBA(t *testing.T) {
+	picture := readSheet(t, "rgba.png")
+	sheet := Quantize(picture, sweetie(t), false, 5)
+
+	for x := 0; x < 8; x++ {
+		for y, want := range []int{x, x + 8, x, 5, 12, 12, 12, 12} {
+			if index := int(sheet.ColorIndexAt(x, y)); index != want {
+				t.Errorf("pixel (%d, %d) = %d, want %d", x, y, index, want)
+			}
+		}
+	}
+
+	dithered := Quantize(picture, sweetie(t), true, 5)
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 8; x++ {
+			if dithered.ColorIndexAt(x, y) != sheet.ColorIndexAt(x, y) {
+				t.Errorf("dithered pixel (%d, %d) = %d, want %d", x, y, dithered.ColorIndexAt(x, y), sheet.ColorIndexAt(x, y))
+			}
+		}
+	}
+}
+
+func TestImportErrors(t *testing.T) {
+	sheet := Quantize(readSheet(t, "rgba.png"), sweetie(t), false, 0)
+
+	var bank tic80.TileMemory
+	if _, err := Import(&bank, sheet, []Region{{"outside", 0, 0, 2, 1}}, nil); err == nil {
+		t.Error("Import accepted a region outside of the sheet")
+	}
+	if _, err := Import(&bank, sheet, []Region{{"full", 0, 0, 1, 1}}, NewImportOptions().SetFirst(SHEET_COLUMNS*SHEET_ROWS-1).ToggleSprites()); err != nil {
+		t.Errorf("Import into the last tile: %v", err)
+	}
+	wide := Quantize(readSheet(t, "indexed.png"), sweetie(t), false, 0)
+	if _, err := Import(&bank, wide, []Region{{"wide", 0, 0, 2, 1}}, NewImportOptions().SetFirst(SHEET_COLUMNS*SHEET_ROWS-1)); err == nil {
+		t.Error("Import placed a region in a bank without room for it")
+	}
+	if _, err := ParseRegions(strings.NewReader("name 0 0 1\n")); err == nil {
+		t.Error("ParseRegions accepted a line with four fields")
+	}
+	if _, err := ParseRegions(strings.NewReader("name 0 -1 1 1\n")); err == nil {
+		t.Error("ParseRegions accepted a negative number")
+	}
+}
@@ -0,0 +1,180 @@
+package tic80
+
+// Key Repeat Defaults
+const (
+	TEXT_REPEAT_HOLD   = 20
+	TEXT_REPEAT_PERIOD = 3
+)
+
+// keyRunes are the runes of the keys from [tic80.KEY_A] to [tic80.KEY_SPACE] in the US layout, without and with shift.
+var keyRunes = [2]string{
+	"abcdefghijklmnopqrstuvwxyz0123456789-=[]\\;'`,./ ",
+	"ABCDEFGHIJKLMNOPQRSTUVWXYZ)!@#$%^&*(_+{}|:\"~<>? ",
+}
+
+// KeyRune returns the rune typed by a key in the US layout, and true if the key types one.
+// Caps lock only affects letters.
+func KeyRune(id KeyCode, shift, capsLock bool) (rune, bool) {
+	if id < KEY_A || id > KEY_SPACE {
+		return 0, false
+	}
+	index := int(id - KEY_A)
+	if id <= KEY_Z && capsLock {
+		shift = !shift
+	}
+	if shift {
+		return rune(keyRunes[1][index]), true
+	}
+	return rune(keyRunes[0][index]), true
+}
+
+// TextInput reads the keyboard as typed text, with keys repeating while they are held.
+//
+// [tic80.TextInput.Update] must be called once every frame, from [tic80.Game.Tic].
+type TextInput struct {
+	hold   int
+	period int
+	typed  []rune
+}
+
+// NewTextInput constructs a [tic80.TextInput] object that repeats keys held for [tic80.TEXT_REPEAT_HOLD] frames
+// every [tic80.TEXT_REPEAT_PERIOD] frames.
+func NewTextInput() *TextInput {
+	return &TextInput{
+		hold:   TEXT_REPEAT_HOLD,
+		period: TEXT_REPEAT_PERIOD,
+	}
+}
+
+// SetRepeat sets the number of frames a key must be held before it repeats, and the number of frames between repeats.
+func (input *TextInput) SetRepeat(hold, period int) *TextInput {
+	input.hold = hold
+	input.period = period
+	return input
+}
+
+// Repeated returns true if a key was pressed this frame, or is repeating this frame; false otherwise.
+func (input *TextInput) Repeated(id KeyCode) bool {
+	return Keyp(id, input.hold, input.period)
+}
+
+// Update reads the runes typed this frame.
+// Nothing is typed while control or alt is held, so that shortcuts do not type text.
+func (input *TextInput) Update() {
+	input.typed = input.typed[:0]
+	if Key(KEY_CTRL) || Key(KEY_ALT) {
+		return
+	}
+	shift, capsLock := Key(KEY_SHIFT), Key(KEY_CAPSLOCK)
+	for id := KEY_A; id <= KEY_SPACE; id++ {
+		if input.Repeated(id) {
+			typed, _ := KeyRune(id, shift, capsLock)
+			input.typed = append(input.typed, typed)
+		}
+	}
+}
+
+// Typed returns the runes typed this frame.
+func (input *TextInput) Typed() []rune {
+	return input.typed
+}
+
+// LineField is an editable line of text, typed with a [tic80.TextInput] and drawn with [tic80.Print].
+type LineField struct {
+	text      []rune
+	cursor    int
+	maxLength int
+	blink     int
+}
+
+// NewLineField constructs an empty [tic80.LineField] object that holds up to the specified number of runes.
+func NewLineField(maxLength int) *LineField {
+	return &LineField{maxLength: maxLength}
+}
+
+// SetText sets the text, cut to the maximum length, and moves the cursor to its end.
+func (field *LineField) SetText(text string) *LineField {
+	field.text = []rune(text)
+	if len(field.text) > field.maxLength {
+		field.text = field.text[:field.maxLength]
+	}
+	field.cursor = len(field.text)
+	return field
+}
+
+// Text returns the text.
+func (field *LineField) Text() string {
+	return string(field.text)
+}
+
+// Cursor returns the position of the cursor, from 0 before the first rune to the length of the text after the last.
+func (field *LineField) Cursor() int {
+	return field.cursor
+}
+
+// SetCursor moves the cursor to the specified position.
+func (field *LineField) SetCursor(position int) {
+	field.cursor = maximum(0, minimum(position, len(field.text)))
+	field.blink = 0
+}
+
+// Insert types a rune at the cursor, unless the text is already at its maximum length.
+// It returns true if the rune was typed; false otherwise.
+func (field *LineField) Insert(typed rune) bool {
+	if len(field.text) >= field.maxLength {
+		return false
+	}
+	field.text = append(field.text[:field.cursor], append([]rune{typed}, field.text[field.cursor:]...)...)
+	field.SetCursor(field.cursor + 1)
+	return true
+}
+
+// Erase erases the rune before the cursor, if there is one.
+func (field *LineField) Erase() {
+	if field.cursor > 0 {
+		field.text = append(field.text[:field.cursor-1], field.text[field.cursor:]...)
+		field.SetCursor(field.cursor - 1)
+	}
+}
+
+// Update types the runes typed this frame at the cursor, and handles the editing keys:
+// [tic80.KEY_LEFT] and [tic80.KEY_RIGHT] move the cursor, [tic80.KEY_HOME] and [tic80.KEY_END] move it to either end,
+// and [tic80.KEY_BACKSPACE] and [tic80.KEY_DELETE] erase the rune before or after it.
+// It returns true if [tic80.KEY_RETURN] was pressed this frame; false otherwise.
+func (field *LineField) Update(input *TextInput) bool {
+	field.blink++
+	for _, typed := range input.Typed() {
+		field.Insert(typed)
+	}
+
+	switch {
+	case input.Repeated(KEY_LEFT):
+		field.SetCursor(field.cursor - 1)
+	case input.Repeated(KEY_RIGHT):
+		field.SetCursor(field.cursor + 1)
+	case input.Repeated(KEY_HOME):
+		field.SetCursor(0)
+	case input.Repeated(KEY_END):
+		field.SetCursor(len(field.text))
+	case input.Repeated(KEY_BACKSPACE):
+		field.Erase()
+	case input.Repeated(KEY_DELETE):
+		if field.cursor < len(field.text) {
+			field.text = append(field.text[:field.cursor], field.text[field.cursor+1:]...)
+			field.SetCursor(field.cursor)
+		}
+	}
+	return Keyp(KEY_RETURN, -1, -1)
+}
+
+// Draw prints the text at the specified position, with a blinking cursor in the color of the text.
+func (field *LineField) Draw(x, y int, options *PrintOptions) {
+	if options == nil {
+		options = &defaultPrintOptions
+	}
+	width := Print(string(field.text[:field.cursor]), x, y, options)
+	Print(string(field.text[field.cursor:]), x+width, y, options)
+	if field.blink%32 < 16 {
+		Rect(x+width-options.scale, y, options.scale, 6*options.scale, int(options.color))
+	}
+}
@@ -5,12 +5,6 @@ import (
 	"unsafe"
 )
 
-// Memory Areas
-var (
-	IO_RAM   = (*[0x18000]byte)(unsafe.Pointer(uintptr(0x00000)))
-	FREE_RAM = (*[0x28000]byte)(unsafe.Pointer(uintptr(0x18000)))
-)
-
 // toTextData transforms a Go string into a form useable by TIC-80.
 func toTextData(goString *string) unsafe.Pointer {
 	textData := new([]byte)
@@ -739,9 +733,6 @@ func (options *TraceOptions) SetColor(color int) *TraceOptions {
 	return options
 }
 
-//go:export btn
-func rawBtn(id int32) int32
-
 // Btn returns true if the controller button specified by the given id is pressed; false otherwise.
 // See the [API] for more details.
 //
@@ -750,9 +741,6 @@ func Btn(id ButtonCode) bool {
 	return rawBtn(int32(id%32)) > 0
 }
 
-//go:export btnp
-func rawBtnp(id, hold, period int32) bool
-
 // Btnp returns true if the controller button specified by the given id was pressed the last frame, or after hold every period frames; false otherwise.
 // See the [API] for more details.
 //
@@ -761,9 +749,6 @@ func Btnp(id ButtonCode, hold, period int) bool {
 	return rawBtnp(int32(id%32), int32(hold), int32(period))
 }
 
-//go:export clip
-func rawClip(x, y, width, height int32)
-
 // Clip sets the clipping region for the screen.
 // See the [API] for more details.
 //
@@ -772,9 +757,6 @@ func Clip(x, y, width, height int) {
 	rawClip(int32(x), int32(y), int32(width), int32(height))
 }
 
-//go:export cls
-func rawCls(color int8)
-
 // Cls fills the screen with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -783,9 +765,6 @@ func Cls(color int) {
 	rawCls(int8(color))
 }
 
-//go:export circ
-func rawCirc(x, y, radius int32, color int8)
-
 // Circ draws a filled circle with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -794,9 +773,6 @@ func Circ(x, y, radius, color int) {
 	rawCirc(int32(x), int32(y), int32(radius), int8(color%16))
 }
 
-//go:export circb
-func rawCircb(x, y, radius int32, color int8)
-
 // Circb draws a circle border with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -805,25 +781,16 @@ func Circb(x, y, radius, color int) {
 	rawCircb(int32(x), int32(y), int32(radius), int8(color%16))
 }
 
-//go:export elli
-func rawElli(x, y, radiusX, radiusY int32, color int8)
-
 // Elli draws a filled ellipse with the specified color to the screen.
 func Elli(x, y, radiusX, radiusY, color int) {
 	rawElli(int32(x), int32(y), int32(radiusX), int32(radiusY), int8(color%16))
 }
 
-//go:export ellib
-func rawEllib(x, y, radiusX, radiusY int32, color int8)
-
 // Ellib draws an ellipse border with the specified color to the screen.
 func Ellib(x, y, radiusX, radiusY, color int) {
 	rawEllib(int32(x), int32(y), int32(radiusX), int32(radiusY), int8(color%16))
 }
 
-//go:export exit
-func rawExit()
-
 // Exit closes TIC-80.
 // See the [API] for more details.
 //
@@ -832,9 +799,6 @@ func Exit() {
 	rawExit()
 }
 
-//go:export fget
-func rawFget(sprite int32, flag int8) bool
-
 // Fget gets the status of the specified flag of the specified sprite.
 // See the [API] for more details.
 //
@@ -843,9 +807,6 @@ func Fget(sprite, flag int) bool {
 	return rawFget(int32(sprite%512), int8(flag%8))
 }
 
-//go:export fset
-func rawFset(sprite int32, flag int8, value bool)
-
 // Fset sets the status of the specified flag of the specified sprite.
 // See the [API] for more details.
 //
@@ -854,9 +815,6 @@ func Fset(sprite, flag int, value bool) {
 	rawFset(int32(sprite%512), int8(flag%8), value)
 }
 
-//go:export font
-func rawFont(textBuffer unsafe.Pointer, x, y int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, characterWidth, characterHeight int8, fixed bool, scale int8, useAlternateFontPage bool) int32
-
 // Font draws text to the screen using sprite data.
 // See the [API] for more details.
 //
@@ -873,9 +831,6 @@ func Font(text string, x, y int, options *FontOptions) (textWidth int) {
 	return int(rawFont(textBuffer, int32(x), int32(y), transparentColorBuffer, int8(transparentColorCount), int8(options.characterWidth), int8(options.characterHeight), options.fixed, int8(options.scale), options.alternateFont))
 }
 
-//go:export key
-func rawKey(id int32) int32
-
 // Key returns true if keyboard key specified by the id was pressed; false otherwise.
 // See the [API] for more details.
 //
@@ -884,9 +839,6 @@ func Key(id KeyCode) bool {
 	return rawKey(int32(id)) > 0
 }
 
-//go:export keyp
-func rawKeyp(id int8, hold, period int32) int32
-
 // Keyp returns true if the keyboard key specified by the given id was pressed the last frame, or after hold every period frames; false otherwise.
 // See the [API] for more details.
 //
@@ -895,9 +847,6 @@ func Keyp(id KeyCode, hold, period int) bool {
 	return rawKeyp(int8(id), int32(hold), int32(period)) > 0
 }
 
-//go:export line
-func rawLine(x0, y0, x1, y1 float32, color int8)
-
 // Line draws a line with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -906,9 +855,6 @@ func Line(x0, y0, x1, y1, color int) {
 	rawLine(float32(x0), float32(y0), float32(x1), float32(y1), int8(color))
 }
 
-//go:export map
-func rawMap(x, y, width, height, screenX, screenY int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, unused int32)
-
 // Map draws a tile map to the screen.
 // See the [API] for more details.
 //
@@ -924,9 +870,6 @@ func Map(options *MapOptions) {
 	rawMap(int32(options.x), int32(options.y), int32(options.width), int32(options.height), int32(options.screenX), int32(options.screenY), transparentColorBuffer, int8(transparentColorCount), 0)
 }
 
-//go:export memcpy
-func rawMemcpy(destination, source, length int32)
-
 // Memcpy copies a buffer of RAM to RAM.
 // See the [API] for more details.
 //
@@ -935,9 +878,6 @@ func Memcpy(destination, source, length int) {
 	rawMemcpy(int32(destination), int32(source), int32(length))
 }
 
-//go:export memset
-func rawMemset(address, value, length int32)
-
 // Memset sets a buffer of RAM to one value.
 // See the [API] for more details.
 //
@@ -946,9 +886,6 @@ func Memset(address, value, length int) {
 	rawMemset(int32(address), int32(value), int32(length))
 }
 
-//go:export mget
-func rawMget(x, y int32) int32
-
 // Mget gets the id of a tile given by the specified coordinates on the map.
 // See the [API] for more details.
 //
@@ -957,9 +894,6 @@ func Mget(x, y int) int {
 	return int(rawMget(int32(x), int32(y)))
 }
 
-//go:export mset
-func rawMset(x, y, value int32)
-
 // Mset sets the specified id of a tile given by the specified coordinates on the map.
 // See the [API] for more details.
 //
@@ -980,9 +914,6 @@ type mouseData struct {
 
 var mouse *mouseData = new(mouseData)
 
-//go:export mouse
-func rawMouse(data *mouseData)
-
 // Mouse returns the current state of the mouse.
 // See the [API] for more details.
 //
@@ -1000,9 +931,6 @@ func Mouse() (x, y int, left, middle, right bool, scrollX, scrollY int) {
 	return
 }
 
-//go:export music
-func rawMusic(track, frame, row int32, loop, sustain bool, tempo, speed int32)
-
 // Music plays a music track.
 // See the [API] for more details.
 //
@@ -1015,9 +943,6 @@ func Music(options *MusicOptions) {
 	rawMusic(int32(options.track), int32(options.frame), int32(options.row), options.loop, options.sustain, int32(options.tempo), int32(options.speed))
 }
 
-//go:export peek
-func rawPeek(address int32, bits int8) int8
-
 // Peek reads a byte from RAM.
 // See the [API] for more details.
 //
@@ -1050,9 +975,6 @@ func Peek1(address int) byte {
 	return byte(rawPeek(int32(address), 1))
 }
 
-//go:export pix
-func rawPix(x, y int32, color int8) uint8
-
 // Pix draws a pixel to the screen, and returns the original color.
 // See the [API] for more details.
 //
@@ -1061,9 +983,6 @@ func Pix(x, y, color int) int {
 	return int(rawPix(int32(x), int32(y), int8(color%16)))
 }
 
-//go:export pmem
-func rawPmem(address int32, value int64) uint32
-
 // Pmem reads and writes values to persistent memory.
 // See the [API] for more details.
 //
@@ -1072,9 +991,6 @@ func Pmem(address int, value int64) uint32 {
 	return rawPmem(int32(address), value)
 }
 
-//go:export poke
-func rawPoke(address int32, value, bits int8)
-
 // Poke writes a byte to RAM.
 // See the [API] for more details.
 //
@@ -1107,9 +1023,6 @@ func Poke1(address int, value byte) {
 	rawPoke(int32(address), int8(value), 1)
 }
 
-//go:export print
-func rawPrint(textBuffer unsafe.Pointer, x, y int32, color, fixed, scale, useAlternateFontPage int8) int32
-
 // Print prints text to the screen using the system fonts.
 // See the [API] for more details.
 //
@@ -1134,9 +1047,6 @@ func Print(text string, x, y int, options *PrintOptions) int {
 	return int(rawPrint(textBuffer, int32(x), int32(y), int8(options.color), optionFixed, int8(options.scale), optionAlternateFont))
 }
 
-//go:export rect
-func rawRect(x, y, width, height int32, color int8)
-
 // Rect draws a filled rectangle with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -1145,9 +1055,6 @@ func Rect(x, y, width, height, color int) {
 	rawRect(int32(x), int32(y), int32(width), int32(height), int8(color%16))
 }
 
-//go:export rectb
-func rawRectb(x, y, width, height int32, color int8)
-
 // Rectb draws a rectangle border with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -1156,9 +1063,6 @@ func Rectb(x, y, width, height, color int) {
 	rawRectb(int32(x), int32(y), int32(width), int32(height), int8(color%16))
 }
 
-//go:export sfx
-func rawSfx(id, note, octave, duration, channel, volumeLeft, volumeRight, speed int32)
-
 // Sfx plays a sound effect.
 // See the [API] for more details.
 //
@@ -1171,9 +1075,6 @@ func Sfx(options *SoundEffectOptions) {
 	rawSfx(int32(options.id), int32(options.note), int32(options.octave), int32(options.duration), int32(options.channel), int32(options.leftVolume), int32(options.rightVolume), int32(options.speed))
 }
 
-//go:export spr
-func rawSpr(id, x, y int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, scale, flip, rotate, width, height int32)
-
 // Spr draws a sprite to the screen.
 // See the [API] for more details.
 //
@@ -1189,9 +1090,6 @@ func Spr(id, x, y int, options *SpriteOptions) {
 	rawSpr(int32(id), int32(x), int32(y), transparentColorBuffer, int8(transparentColorCount), int32(options.scale), int32(options.flip), int32(options.rotate), int32(options.width), int32(options.height))
 }
 
-//go:export sync
-func rawSync(mask int32, bank, toCart int8)
-
 // Sync exchanges and optionally persists the changes of data banks.
 // See the [API] for more details.
 //
@@ -1205,9 +1103,6 @@ func Sync(mask SyncMask, bank int, toCart bool) {
 	rawSync(int32(mask), int8(bank), toCartValue)
 }
 
-//go:export ttri
-func rawTtri(x0, y0, x1, y1, x2, y2, u0, v0, u1, v1, u2, v2 float32, useTiles int32, transparentColorBuffer unsafe.Pointer, transparentColorCount int8, z0, z1, z2 float32, depth bool)
-
 // Ttri draws a textured triangle using sprites or tiles as its texture to the screen.
 // See the [API] for more details.
 //
@@ -1228,9 +1123,6 @@ func Ttri(x0, y0, x1, y1, x2, y2, u0, v0, u1, v1, u2, v2 int, options *TexturedT
 	rawTtri(float32(x0), float32(y0), float32(x1), float32(y1), float32(x2), float32(y2), float32(u0), float32(v0), float32(u1), float32(v1), float32(u2), float32(v2), useTilesValue, transparentColorBuffer, int8(transparentColorCount), float32(options.z0), float32(options.z1), float32(options.z2), options.useDepthCalculations)
 }
 
-//go:export time
-func rawTime() float32
-
 // Time returns the number of milliseconds since the game started.
 // See the [API] for more details.
 //
@@ -1239,9 +1131,6 @@ func Time() float32 {
 	return rawTime()
 }
 
-//go:export trace
-func rawTrace(messageBuffer unsafe.Pointer, color int8)
-
 // Trace writes text to the console.
 // See the [API] for more details.
 //
@@ -1256,9 +1145,6 @@ func Trace(message string, options *TraceOptions) {
 	rawTrace(messageBuffer, int8(options.color))
 }
 
-//go:export tri
-func rawTri(x0, y0, x1, y1, x2, y2 float32, color int8)
-
 // Tri draws a filled triangle with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -1267,9 +1153,6 @@ func Tri(x0, y0, x1, y1, x2, y2, color int) {
 	rawTri(float32(x0), float32(y0), float32(x1), float32(y1), float32(x2), float32(y2), int8(color))
 }
 
-//go:export trib
-func rawTrib(x0, y0, x1, y1, x2, y2 float32, color int8)
-
 // Trib draws a triangle border with the specified color to the screen.
 // See the [API] for more details.
 //
@@ -1278,9 +1161,6 @@ func Trib(x0, y0, x1, y1, x2, y2, color int) {
 	rawTrib(float32(x0), float32(y0), float32(x1), float32(y1), float32(x2), float32(y2), int8(color))
 }
 
-//go:export tstamp
-func rawTstamp() uint32
-
 // Tstamp returns the current Unix timestamp.
 // See the [API] for more details.
 //
@@ -1289,11 +1169,13 @@ func Tstamp() uint32 {
 	return rawTstamp()
 }
 
-// Start is a workaround to allow TIC-80 to run Go code.
-// This should be the first function run in BOOT.
+// Vbank switches the video bank, and returns the previous video bank.
+// See the [API] for more details.
 //
-//go:linkname Start _start
-func Start()
+// [API]: https://github.com/nesbox/TIC-80/wiki/vbank
+func Vbank(id int) (previous int) {
+	return int(rawVbank(int8(id % 2)))
+}
 
 //go:export main.main
 func main() {}
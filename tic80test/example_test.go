@@ -0,0 +1,31 @@
+package tic80test_test
+
+import (
+	"fmt"
+
+	"github.com/sorucoder/tic80"
+	"github.com/sorucoder/tic80/tic80test"
+)
+
+// jumper is a game that draws a player who jumps while the button is held.
+type jumper struct {
+	tic80.BaseGame
+	y int
+}
+
+func (game *jumper) Tic() {
+	if tic80.Btn(tic80.BUTTON_A) {
+		game.y--
+	}
+	tic80.Cls(0)
+	tic80.Pix(0, 100+game.y, 12)
+}
+
+func ExampleRun() {
+	script := tic80test.Script{}.Hold(0, 3, tic80test.Input{Buttons: []tic80.ButtonCode{tic80.BUTTON_A}})
+	tic80test.Run(&jumper{}, 10, script)
+
+	snapshot := tic80test.Snapshot()
+	fmt.Println(snapshot.ColorIndexAt(0, 97), snapshot.ColorIndexAt(0, 100))
+	// Output: 12 0
+}
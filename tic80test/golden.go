@@ -0,0 +1,106 @@
+package tic80test
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// update is namespaced, so that it does not clash with an -update flag of the tests that import this package.
+var update = flag.Bool("tic80test.update", false, "update golden images instead of comparing against them")
+
+// AssertGolden compares the screen against the golden image testdata/<name>.png.
+// When they do not match, the test fails, and the screen and a diff image are written next to the golden image,
+// as testdata/<name>.actual.png and testdata/<name>.diff.png.
+//
+// Run the tests with -tic80test.update to write the golden images from the screen instead.
+func AssertGolden(t testing.TB, name string) {
+	t.Helper()
+
+	actual := Snapshot()
+	path := filepath.Join("testdata", name+".png")
+	if *update {
+		if err := writePNG(path, actual); err != nil {
+			t.Fatalf("tic80test: could not update golden image: %v", err)
+		}
+		return
+	}
+
+	golden, err := readPNG(path)
+	if err != nil {
+		t.Fatalf("tic80test: could not read golden image (run with -tic80test.update to create it): %v", err)
+	}
+
+	diff, mismatches := compare(golden, actual)
+	if mismatches == 0 {
+		return
+	}
+
+	actualPath := filepath.Join("testdata", name+".actual.png")
+	diffPath := filepath.Join("testdata", name+".diff.png")
+	if err := writePNG(actualPath, actual); err != nil {
+		t.Errorf("tic80test: could not write actual image: %v", err)
+	}
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Errorf("tic80test: could not write diff image: %v", err)
+	}
+	t.Errorf("tic80test: screen does not match %s in %d pixels; see %s and %s", path, mismatches, actualPath, diffPath)
+}
+
+// compare returns an image of the differences between the golden and actual images, and the number of pixels that differ.
+// Pixels that match are drawn faded, and pixels that differ are drawn in red.
+func compare(golden, actual image.Image) (*image.RGBA, int) {
+	bounds := golden.Bounds().Union(actual.Bounds())
+	diff := image.NewRGBA(bounds)
+	mismatches := 0
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			point := image.Pt(x, y)
+			if point.In(golden.Bounds()) && point.In(actual.Bounds()) && sameColor(golden.At(x, y), actual.At(x, y)) {
+				gray := color.GrayModel.Convert(actual.At(x, y)).(color.Gray)
+				diff.Set(x, y, color.RGBA{gray.Y / 4, gray.Y / 4, gray.Y / 4, 0xFF})
+			} else {
+				diff.Set(x, y, color.RGBA{0xFF, 0x00, 0x00, 0xFF})
+				mismatches++
+			}
+		}
+	}
+	return diff, mismatches
+}
+
+// sameColor returns true if both colors are the same; false otherwise.
+func sameColor(a, b color.Color) bool {
+	aRed, aGreen, aBlue, aAlpha := a.RGBA()
+	bRed, bGreen, bBlue, bAlpha := b.RGBA()
+	return aRed == bRed && aGreen == bGreen && aBlue == bBlue && aAlpha == bAlpha
+}
+
+// readPNG reads a PNG image from a file.
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+// writePNG writes an image to a file as a PNG, creating its directory if needed.
+func writePNG(path string, picture image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, picture); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,106 @@
+package tic80test
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sorucoder/tic80"
+)
+
+// box is a game that draws a box, which moves right while the button is held.
+type box struct {
+	tic80.BaseGame
+	x int
+}
+
+func (game *box) Tic() {
+	if tic80.Btn(tic80.BUTTON_RIGHT) {
+		game.x++
+	}
+	tic80.Cls(0)
+	tic80.Rect(10+game.x, 10, 20, 10, 6)
+}
+
+// recorder is a [testing.TB] that records errors instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (recorder *recorder) Helper() {}
+
+func (recorder *recorder) Errorf(format string, arguments ...any) {
+	recorder.errors = append(recorder.errors, fmt.Sprintf(format, arguments...))
+}
+
+func TestAssertGolden(t *testing.T) {
+	script := Script{}.Hold(0, 5, Input{Buttons: []tic80.ButtonCode{tic80.BUTTON_RIGHT}})
+	Run(&box{}, 10, script)
+	AssertGolden(t, "box")
+}
+
+func TestAssertGoldenMismatch(t *testing.T) {
+	if *update {
+		t.Skip("the mismatch is deliberate, so it must not update the golden image")
+	}
+	actualPath := filepath.Join("testdata", "box.actual.png")
+	diffPath := filepath.Join("testdata", "box.diff.png")
+	t.Cleanup(func() {
+		os.Remove(actualPath)
+		os.Remove(diffPath)
+	})
+
+	// Without the button held, the box stays 5 pixels to the left of the golden image.
+	Run(&box{}, 10, nil)
+	recorder := &recorder{TB: t}
+	AssertGolden(recorder, "box")
+	if len(recorder.errors) != 1 || !strings.Contains(recorder.errors[0], "in 100 pixels") {
+		t.Fatalf("expected one error about 100 pixels, got %q", recorder.errors)
+	}
+
+	actual, err := readPNG(actualPath)
+	if err != nil {
+		t.Fatalf("could not read actual image: %v", err)
+	}
+	if _, mismatches := compare(Snapshot(), actual); mismatches != 0 {
+		t.Errorf("actual image differs from the screen in %d pixels", mismatches)
+	}
+
+	diff, err := readPNG(diffPath)
+	if err != nil {
+		t.Fatalf("could not read diff image: %v", err)
+	}
+	red := color.RGBA{0xFF, 0x00, 0x00, 0xFF}
+	for y := 0; y < tic80.SCREEN_HEIGHT; y++ {
+		for x := 0; x < tic80.SCREEN_WIDTH; x++ {
+			differs := y >= 10 && y < 20 && (x >= 10 && x < 15 || x >= 30 && x < 35)
+			if isRed := sameColor(diff.At(x, y), red); isRed != differs {
+				t.Fatalf("diff pixel (%d, %d) is red: %v, expected %v", x, y, isRed, differs)
+			}
+		}
+	}
+}
+
+func TestSnapshotLayerPalettes(t *testing.T) {
+	tic80.HostReset()
+	overlay := tic80.LAYER_OVERLAY.Palette()
+	overlay[6] = color.RGBA{0x12, 0x34, 0x56, 0xFF}
+	tic80.LAYER_OVERLAY.SetPalette(overlay)
+	tic80.Rect(0, 0, 2, 1, 6)
+	tic80.LAYER_OVERLAY.Draw(func() {
+		tic80.Cls(0)
+		tic80.Pix(1, 0, 6)
+	})
+
+	snapshot := Snapshot()
+	if background := tic80.LAYER_BACKGROUND.Palette()[6]; !sameColor(snapshot.At(0, 0), background) {
+		t.Errorf("background pixel is %v, expected %v", snapshot.At(0, 0), background)
+	}
+	if !sameColor(snapshot.At(1, 0), overlay[6]) {
+		t.Errorf("overlay pixel is %v, expected %v", snapshot.At(1, 0), overlay[6])
+	}
+}
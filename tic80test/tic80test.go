@@ -0,0 +1,77 @@
+// Package tic80test provides utilities for testing games on the host, without TIC-80.
+package tic80test
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/sorucoder/tic80"
+)
+
+// Input is the input held during one frame.
+type Input struct {
+	Buttons []tic80.ButtonCode
+	Keys    []tic80.KeyCode
+}
+
+// Script is the input held during each frame, numbered from 0.
+// Frames without an entry have no input held.
+type Script map[int]Input
+
+// Hold adds the input to each frame from first up to but not including last.
+func (script Script) Hold(first, last int, input Input) Script {
+	for frame := first; frame < last; frame++ {
+		held := script[frame]
+		held.Buttons = append(held.Buttons, input.Buttons...)
+		held.Keys = append(held.Keys, input.Keys...)
+		script[frame] = held
+	}
+	return script
+}
+
+// Run resets the host, then boots the game and runs it for the given number of frames, holding the input of the script.
+// A nil script holds no input.
+func Run(game tic80.Game, frames int, script Script) {
+	tic80.Run(game)
+	tic80.HostReset()
+	for frame := 0; frame < frames; frame++ {
+		input := script[frame]
+		*tic80.GAMEPADS = tic80.GamepadMemory{}
+		for _, button := range input.Buttons {
+			tic80.GAMEPADS.SetPressed(button, true)
+		}
+		tic80.KEYBOARD.SetKeys(input.Keys...)
+		tic80.HostTick()
+	}
+}
+
+// Snapshot returns the screen as it is displayed, with the overlay drawn over the background, each using its own palette.
+// The first 16 colors of the image are the palette of the background, and the next 16 are the palette of the overlay.
+func Snapshot() *image.Paletted {
+	var colors color.Palette
+	for _, layer := range []tic80.Layer{tic80.LAYER_BACKGROUND, tic80.LAYER_OVERLAY} {
+		for _, entry := range layer.Palette() {
+			colors = append(colors, entry)
+		}
+	}
+
+	snapshot := image.NewPaletted(image.Rect(0, 0, tic80.SCREEN_WIDTH, tic80.SCREEN_HEIGHT), colors)
+	tic80.LAYER_BACKGROUND.Draw(func() {
+		for y := 0; y < tic80.SCREEN_HEIGHT; y++ {
+			for x := 0; x < tic80.SCREEN_WIDTH; x++ {
+				snapshot.SetColorIndex(x, y, uint8(tic80.SCREEN.Pixel(x, y)))
+			}
+		}
+	})
+	transparentColor := tic80.LAYER_OVERLAY.BorderColor()
+	tic80.LAYER_OVERLAY.Draw(func() {
+		for y := 0; y < tic80.SCREEN_HEIGHT; y++ {
+			for x := 0; x < tic80.SCREEN_WIDTH; x++ {
+				if index := tic80.SCREEN.Pixel(x, y); index != transparentColor {
+					snapshot.SetColorIndex(x, y, uint8(len(tic80.Palette{})+index))
+				}
+			}
+		}
+	})
+	return snapshot
+}
@@ -0,0 +1,46 @@
+//go:build !tinygo
+
+package tic80
+
+import (
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"testing"
+)
+
+// TestTinyGoTypecheck type checks the package as TinyGo builds it for TIC-80,
+// since the standard toolchain cannot build the functions TIC-80 exports.
+func TestTinyGoTypecheck(t *testing.T) {
+	context := build.Default
+	context.GOOS = "wasip1"
+	context.GOARCH = "wasm"
+	context.BuildTags = []string{"tinygo"}
+	context.CgoEnabled = false
+	pkg, err := context.ImportDir(".", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := token.NewFileSet()
+	var parsed []*ast.File
+	for _, name := range pkg.GoFiles {
+		file, err := parser.ParseFile(files, filepath.Join(pkg.Dir, name), nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed = append(parsed, file)
+	}
+
+	config := types.Config{
+		Importer: importer.ForCompiler(files, "source", nil),
+		Error: func(err error) {
+			t.Error(err)
+		},
+	}
+	config.Check(pkg.ImportPath, files, parsed, nil)
+}
@@ -0,0 +1,193 @@
+package tic80
+
+import "math"
+
+// noteFrequency returns the frequency in hertz of a note, numbered in semitones from C0.
+func noteFrequency(note int) float64 {
+	return 440 * math.Pow(2, float64(note-57)/12)
+}
+
+// NoteFrequency returns the frequency in hertz of a note in the specified octave, as TIC-80 tunes it.
+func NoteFrequency(note SoundEffectNote, octave int) float64 {
+	return noteFrequency(octave*12 + int(note))
+}
+
+// Voice plays notes directly into the registers of a sound channel, without sound effects,
+// shaping them with an ADSR envelope, vibrato and arpeggio.
+//
+// TIC-80 clears the registers at the start of each frame, so [tic80.Voice.Update] must be called every frame,
+// from [tic80.Game.Tic]. It overrides any sound effect or music playing in the channel while it is playing.
+type Voice struct {
+	channel        int
+	waveform       *Waveform
+	volume         int
+	leftVolume     int
+	rightVolume    int
+	attack         int
+	decay          int
+	sustain        int
+	release        int
+	vibratoPeriod  int
+	vibratoDepth   int
+	arpeggioPeriod int
+	arpeggio       []int
+	frequency      float64
+	held           bool
+	playing        bool
+	frame          int
+	releaseFrame   int
+	releaseLevel   float64
+	level          float64
+}
+
+// NewVoice constructs a [tic80.Voice] object that plays in the specified channel,
+// with waveform 0, full volume, and an envelope that sounds as long as a note is held.
+func NewVoice(channel int) *Voice {
+	return &Voice{
+		channel:     channel % 4,
+		waveform:    WAVEFORMS.Waveform(0),
+		volume:      15,
+		leftVolume:  15,
+		rightVolume: 15,
+		sustain:     15,
+	}
+}
+
+// SetWaveform sets the waveform to play, which is read every frame so it can be changed while it plays.
+// A silent waveform is played as noise.
+func (voice *Voice) SetWaveform(waveform *Waveform) *Voice {
+	voice.waveform = waveform
+	return voice
+}
+
+// SetVolume sets the peak volume, from 0 to 15.
+func (voice *Voice) SetVolume(level int) *Voice {
+	voice.volume = level % 16
+	return voice
+}
+
+// SetStereoVolume sets the volume of left and right speakers independently.
+func (voice *Voice) SetStereoVolume(leftLevel, rightLevel int) *Voice {
+	voice.leftVolume = leftLevel % 16
+	voice.rightVolume = rightLevel % 16
+	return voice
+}
+
+// SetEnvelope sets the ADSR envelope of each note.
+// The attack, decay and release are in frames, and the sustain is the level held after the decay, from 0 to 15.
+func (voice *Voice) SetEnvelope(attack, decay, sustain, release int) *Voice {
+	voice.attack = attack
+	voice.decay = decay
+	voice.sustain = sustain % 16
+	voice.release = release
+	return voice
+}
+
+// SetVibrato sets the vibrato, as the period of one cycle in frames and the depth in hundredths of a semitone.
+// A period or depth of 0 turns the vibrato off.
+func (voice *Voice) SetVibrato(period, depth int) *Voice {
+	voice.vibratoPeriod = period
+	voice.vibratoDepth = depth
+	return voice
+}
+
+// SetArpeggio sets the offsets in semitones that the note cycles through, changing every period of frames.
+// No offsets turn the arpeggio off.
+func (voice *Voice) SetArpeggio(period int, offsets ...int) *Voice {
+	voice.arpeggioPeriod = period
+	voice.arpeggio = append([]int(nil), offsets...)
+	return voice
+}
+
+// NoteOn starts playing a note in the specified octave, restarting the envelope.
+func (voice *Voice) NoteOn(note SoundEffectNote, octave int) {
+	voice.FrequencyOn(NoteFrequency(note, octave))
+}
+
+// FrequencyOn starts playing a frequency in hertz, restarting the envelope.
+func (voice *Voice) FrequencyOn(frequency float64) {
+	voice.frequency = frequency
+	voice.held = true
+	voice.playing = true
+	voice.frame = 0
+}
+
+// SetFrequency changes the frequency in hertz of the note being played, without restarting the envelope.
+// It is used to glide a note, or to play a continuous sound such as an engine.
+func (voice *Voice) SetFrequency(frequency float64) {
+	voice.frequency = frequency
+}
+
+// NoteOff releases the note being played, which fades out over the release of the envelope.
+func (voice *Voice) NoteOff() {
+	if voice.held {
+		voice.held = false
+		voice.releaseFrame = voice.frame
+		voice.releaseLevel = voice.level
+	}
+}
+
+// Stop silences the voice immediately.
+func (voice *Voice) Stop() {
+	voice.held = false
+	voice.playing = false
+	voice.level = 0
+}
+
+// Playing returns true if a note is held or is still being released; false otherwise.
+func (voice *Voice) Playing() bool {
+	return voice.playing
+}
+
+// envelopeLevel returns the level of the envelope at the current frame, from 0 to 1.
+func (voice *Voice) envelopeLevel() float64 {
+	sustain := float64(voice.sustain) / 15
+	if !voice.held {
+		elapsed := voice.frame - voice.releaseFrame
+		if elapsed >= voice.release {
+			return 0
+		}
+		return voice.releaseLevel * (1 - float64(elapsed)/float64(voice.release))
+	}
+	switch frame := voice.frame; {
+	case frame < voice.attack:
+		return float64(frame+1) / float64(voice.attack)
+	case frame < voice.attack+voice.decay:
+		return 1 - (1-sustain)*float64(frame-voice.attack+1)/float64(voice.decay)
+	}
+	return sustain
+}
+
+// Update writes the next frame of the voice into the registers of its channel.
+func (voice *Voice) Update() {
+	if !voice.playing {
+		return
+	}
+	voice.level = voice.envelopeLevel()
+	if !voice.held && voice.level == 0 {
+		voice.playing = false
+		return
+	}
+
+	semitones := 0.0
+	if len(voice.arpeggio) > 0 {
+		period := voice.arpeggioPeriod
+		if period < 1 {
+			period = 1
+		}
+		semitones += float64(voice.arpeggio[voice.frame/period%len(voice.arpeggio)])
+	}
+	if voice.vibratoPeriod > 0 && voice.vibratoDepth != 0 {
+		semitones += math.Sin(2*math.Pi*float64(voice.frame)/float64(voice.vibratoPeriod)) * float64(voice.vibratoDepth) / 100
+	}
+	voice.frame++
+
+	frequency := voice.frequency * math.Pow(2, semitones/12)
+	register := SOUND_REGISTERS.Channel(voice.channel)
+	register.SetFrequency(int(math.Round(math.Max(0, math.Min(0x0FFF, frequency)))))
+	register.SetVolume(int(math.Round(voice.level * float64(voice.volume))))
+	if voice.waveform != nil {
+		register.SetWaveform(voice.waveform)
+	}
+	STEREO_VOLUME.SetVolume(voice.channel, voice.leftVolume, voice.rightVolume)
+}